@@ -2,10 +2,14 @@ package main
 
 import (
 	"net/http"
+	"time"
+	_ "time/tzdata"
 
+	"pixelbrew-llc/ktrav3l_backend/config"
 	"pixelbrew-llc/ktrav3l_backend/controllers"
 	"pixelbrew-llc/ktrav3l_backend/initializers"
 	"pixelbrew-llc/ktrav3l_backend/middleware"
+	"pixelbrew-llc/ktrav3l_backend/services"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,12 +17,26 @@ import (
 func init() {
 	initializers.LoadEnvVariables()
 	initializers.ConnectToDB()
+	initializers.ConnectMailer()
 	initializers.SyncDB()
 }
 
 func main() {
+	// Limpiar reservas temporales vencidas
+	services.StartHoldSweeper(time.Minute)
+	// Enviar los emails de la outbox
+	services.StartEmailWorker(30 * time.Second)
+	// Ofrecer franjas liberadas a la lista de espera
+	controllers.StartWaitlistNotifier(time.Minute)
+
 	r := gin.Default()
 
+	// Solo se lee X-Forwarded-For de los proxies configurados; si no, cualquiera podría
+	// cambiar su IP en cada petición y saltarse el rate limit
+	if err := r.SetTrustedProxies(config.Env.TrustedProxies); err != nil {
+		panic("Invalid TRUSTED_PROXIES: " + err.Error())
+	}
+
 	// Aumentar el límite de tamaño del body para archivos (10MB)
 	r.MaxMultipartMemory = 10 << 20 // 10 MB
 
@@ -79,14 +97,30 @@ func main() {
 	r.POST("/sign-in", controllers.SignIn)
 	r.GET("/me", middleware.RequireAuth, controllers.Me)
 
-	// Public appointment routes
+	// Public appointment routes (lookups by code, slot holds and waitlist sign-ups are rate limited per IP)
+	lookupLimit := middleware.RateLimit(config.Env.LookupRateLimit, time.Minute)
+	holdLimit := middleware.RateLimit(config.Env.HoldRateLimit, time.Minute)
+	waitlistLimit := middleware.RateLimit(config.Env.WaitlistRateLimit, time.Minute)
 	r.POST("/appointments", controllers.CreateAppointment)
-	r.GET("/appointments/short/:shortID", controllers.GetAppointmentByShortID)
-	r.GET("/appointments/receipt/:shortID", controllers.GetReceipt)
+	r.POST("/appointments/holds", holdLimit, controllers.CreateSlotHold)
+	r.DELETE("/appointments/holds/:token", controllers.ReleaseSlotHold)
+	r.GET("/appointments/short/:shortID", lookupLimit, controllers.GetAppointmentByShortID)
+	r.GET("/appointments/short/:shortID/calendar.ics", lookupLimit, controllers.GetAppointmentCalendar)
+	r.POST("/appointments/short/:shortID/cancel", lookupLimit, controllers.CancelAppointmentByClient)
+	r.POST("/appointments/short/:shortID/reschedule", lookupLimit, controllers.RescheduleAppointmentByClient)
+	r.GET("/appointments/receipt/:shortID", lookupLimit, controllers.GetReceipt)
 	r.GET("/appointments/available-hours", controllers.GetAvailableHours)
+	r.GET("/appointments/availability", controllers.GetAvailabilityRange)
 	r.GET("/appointments/types", controllers.GetAppointmentTypes)
+	r.POST("/appointments/waitlist", waitlistLimit, controllers.JoinWaitlist)
+	r.POST("/appointments/waitlist/confirm/:token", lookupLimit, controllers.ConfirmWaitlist)
+	r.DELETE("/appointments/waitlist/:id", controllers.LeaveWaitlist)
+	r.GET("/appointments/waitlist/claim/:token", lookupLimit, controllers.GetWaitlistClaim)
 	r.GET("/bank-accounts", controllers.GetBankAccounts)
 
+	// Calendar feed (authenticated by the secret token in the URL)
+	r.GET("/calendar/feed/:token", lookupLimit, controllers.GetCalendarFeed)
+
 	// Admin routes (protected)
 	admin := r.Group("/admin")
 	admin.Use(middleware.RequireAuth)
@@ -95,30 +129,47 @@ func main() {
 		admin.GET("/appointments", controllers.GetAllAppointments)
 		admin.GET("/appointments/:id", controllers.GetAppointmentByID)
 		admin.GET("/appointments/:id/receipt", controllers.GetReceiptByID)
+		admin.GET("/appointments/:id/history", controllers.GetAppointmentHistory)
 		admin.POST("/appointments/:id/approve", controllers.ApproveAppointment)
 		admin.POST("/appointments/:id/reject", controllers.RejectAppointment)
 		admin.POST("/appointments/:id/done", controllers.MarkAppointmentDone)
+		admin.POST("/appointments/:id/no-show", controllers.MarkAppointmentNoShow)
 		admin.PATCH("/appointments/:id/move", controllers.MoveAppointment)
 		admin.PATCH("/appointments/:id/platform", controllers.UpdateAppointmentPlatform)
 		admin.PATCH("/appointments/:id/details", controllers.UpdateAppointmentDetails)
 		admin.POST("/appointments", controllers.AdminCreateAppointment)
 		admin.GET("/calendar", controllers.GetCalendarData)
+		admin.GET("/calendar-feed", controllers.GetCalendarFeedURL)
+		admin.POST("/calendar-feed/rotate", controllers.RotateCalendarFeed)
 		admin.GET("/dashboard-stats", controllers.GetDashboardStats)
+		admin.GET("/waitlist", controllers.GetWaitlist)
+		admin.GET("/emails", controllers.GetOutboxEmails)
+		admin.POST("/emails/:id/retry", controllers.RetryOutboxEmail)
 
 		// Appointment types management
 		admin.GET("/appointment-types", controllers.GetAllAppointmentTypes)
 		admin.POST("/appointment-types", controllers.CreateAppointmentType)
 		admin.PATCH("/appointment-types/:id/visibility", controllers.UpdateAppointmentTypeVisibility)
+		admin.PATCH("/appointment-types/:id/duration", controllers.UpdateAppointmentTypeDuration)
+		admin.PATCH("/appointment-types/:id/capacity", controllers.UpdateAppointmentTypeCapacity)
+		admin.PATCH("/appointment-types/:id/booking-window", controllers.UpdateAppointmentTypeBookingWindow)
 
 		// Availability rules management
 		admin.GET("/availability-rules", controllers.GetAvailabilityRules)
 		admin.POST("/availability-rules/weekday", controllers.CreateWeekdayRule)
 		admin.POST("/availability-rules/specific-date", controllers.CreateSpecificDateRule)
+		admin.POST("/availability-rules/range", controllers.ApplyRuleRange)
 		admin.PUT("/availability-rules/:id", controllers.UpdateAvailabilityRule)
 		admin.DELETE("/availability-rules/:id", controllers.DeleteAvailabilityRule)
 		admin.DELETE("/availability-rules/weekday/:day", controllers.DeleteWeekdayRule)
 		admin.DELETE("/availability-rules/specific-date/:date", controllers.DeleteSpecificDateRule)
 
+		// Recurring rules and holiday calendars
+		admin.GET("/recurring-rules", controllers.GetRecurringRules)
+		admin.POST("/recurring-rules", controllers.CreateRecurringRule)
+		admin.DELETE("/recurring-rules/:id", controllers.DeleteRecurringRule)
+		admin.POST("/recurring-rules/import-ical", controllers.ImportHolidayCalendar)
+
 		// Bank accounts management
 		admin.GET("/bank-accounts", controllers.GetBankAccounts)
 		admin.POST("/bank-accounts", controllers.CreateBankAccount)
@@ -130,6 +181,12 @@ func main() {
 		admin.POST("/meeting-platforms", controllers.CreateMeetingPlatform)
 		admin.PATCH("/meeting-platforms/:id", controllers.UpdateMeetingPlatform)
 		admin.DELETE("/meeting-platforms/:id", controllers.DeleteMeetingPlatform)
+
+		// Advisors management
+		admin.GET("/advisors", controllers.GetAdvisors)
+		admin.POST("/advisors", controllers.CreateAdvisor)
+		admin.PATCH("/advisors/:id", controllers.UpdateAdvisor)
+		admin.DELETE("/advisors/:id", controllers.DeleteAdvisor)
 	}
 
 	r.Run()
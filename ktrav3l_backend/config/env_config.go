@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type EnvConfig struct {
 	//Environment
 	Environment string
@@ -15,6 +17,11 @@ type EnvConfig struct {
 	JWTSecret string
 	// File Storage
 	UploadsPath string
+	// Email
+	MailTransport string // smtp, file (maildir en MailDir) o memory
+	MailDir       string
+	// Directorio opcional con plantillas de email que reemplazan a las incluidas
+	EmailTemplatesDir string
 	// Email SMTP
 	SMTPHost     string
 	SMTPPort     string
@@ -22,8 +29,39 @@ type EnvConfig struct {
 	SMTPPassword string
 	SMTPFrom     string
 	SMTPFromName string
+	EmailReplyTo string // Opcional: dirección a la que responden los clientes
+	SMTPSecurity string // starttls, tls o none
+	// Intentos de envío de un email antes de marcarlo como fallido
+	EmailMaxAttempts int
 	// Frontend
 	FrontendURL string
+	// Scheduling
+	SlotIntervalMinutes int // Separación entre inicios de franjas ofrecidas
+	SlotCapacity        int // Citas simultáneas permitidas por franja
+	SlotHoldMinutes     int // Duración de una reserva temporal de franja
+	MaxHoldsPerClient   int // Reservas temporales vigentes permitidas por IP
+	MinLeadMinutes      int // Antelación mínima para reservar una franja
+	MaxHorizonDays      int // Días hacia adelante que se pueden reservar (0 = sin límite)
+	// Minutos que tiene un cliente de la lista de espera para tomar la franja ofrecida
+	WaitlistClaimMinutes int
+	// Antelación mínima con la que un cliente puede cancelar o reprogramar su cita
+	SelfServiceCutoffMinutes int
+	// Security
+	LookupRateLimit int // Consultas por minuto y por IP a las rutas públicas por código
+	HoldRateLimit   int // Reservas temporales de franja por minuto y por IP
+	// Inscripciones a la lista de espera por minuto y por IP
+	WaitlistRateLimit int
+	// IPs o rangos CIDR de los proxies (balanceador, CDN) en los que se confía para leer
+	// X-Forwarded-For; vacío = ninguno, se usa la IP de la conexión
+	TrustedProxies []string
+	// Zona horaria en la que se definen fechas, horas y reglas de disponibilidad
+	BusinessTimezone string
+	BusinessLocation *time.Location
+}
+
+// BusinessNow devuelve la hora actual en la zona horaria del negocio
+func (e *EnvConfig) BusinessNow() time.Time {
+	return time.Now().In(e.BusinessLocation)
 }
 
 var Env *EnvConfig
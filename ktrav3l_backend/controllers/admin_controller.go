@@ -1,60 +1,25 @@
 package controllers
 
 import (
-	"fmt"
 	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
 	"pixelbrew-llc/ktrav3l_backend/initializers"
 	"pixelbrew-llc/ktrav3l_backend/models"
-	"pixelbrew-llc/ktrav3l_backend/services"
+	"pixelbrew-llc/ktrav3l_backend/services/availability"
 	"regexp"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-// Helper para formatear fechas en español
-func formatSpanishDate(t time.Time) string {
-	weekdays := map[time.Weekday]string{
-		time.Monday:    "lunes",
-		time.Tuesday:   "martes",
-		time.Wednesday: "miércoles",
-		time.Thursday:  "jueves",
-		time.Friday:    "viernes",
-		time.Saturday:  "sábado",
-		time.Sunday:    "domingo",
-	}
-
-	months := map[time.Month]string{
-		time.January:   "enero",
-		time.February:  "febrero",
-		time.March:     "marzo",
-		time.April:     "abril",
-		time.May:       "mayo",
-		time.June:      "junio",
-		time.July:      "julio",
-		time.August:    "agosto",
-		time.September: "septiembre",
-		time.October:   "octubre",
-		time.November:  "noviembre",
-		time.December:  "diciembre",
-	}
-
-	weekday := weekdays[t.Weekday()]
-	month := months[t.Month()]
-
-	// Capitalizar primera letra del día
-	weekday = strings.ToUpper(string(weekday[0])) + weekday[1:]
-
-	return fmt.Sprintf("%s, %d de %s de %d", weekday, t.Day(), month, t.Year())
-}
-
 // GetAllAppointments obtiene todas las citas con filtros
 func GetAllAppointments(c *gin.Context) {
 	var appointments []models.Appointment
-	query := initializers.DB.Preload("AppointmentType").Preload("BankAccount").Preload("MeetingPlatform")
+	query := initializers.DB.Preload("AppointmentType").Preload("BankAccount").Preload("MeetingPlatform").Preload("Advisor")
 
 	// Filtros opcionales
 	if status := c.Query("status"); status != "" {
@@ -92,8 +57,8 @@ func GetAppointmentByID(c *gin.Context) {
 	id := c.Param("id")
 
 	var appointment models.Appointment
-	if err := initializers.DB.Preload("AppointmentType").Preload("MeetingPlatform").First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+	if err := initializers.DB.Preload("AppointmentType").Preload("MeetingPlatform").Preload("Advisor").First(&appointment, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
 		return
 	}
 
@@ -109,6 +74,7 @@ func ApproveAppointment(c *gin.Context) {
 	var body struct {
 		MeetingLink string `json:"meetingLink"`
 		AdminNote   string `json:"adminNote"`
+		AdvisorID   string `json:"advisorId"` // Opcional: si no se indica se asigna el primer asesor libre
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
@@ -117,28 +83,40 @@ func ApproveAppointment(c *gin.Context) {
 	}
 
 	var appointment models.Appointment
-	if err := initializers.DB.Preload("AppointmentType").First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+	if err := initializers.DB.Preload("AppointmentType").Preload("MeetingPlatform").First(&appointment, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
 		return
 	}
 
-	if appointment.Status == models.StatusDone {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot modify completed appointment"})
+	before := appointment
+	if err := appointment.TransitionTo(models.StatusApproved); err != nil {
+		respondTransitionError(c, err)
 		return
 	}
 
-	appointment.Status = models.StatusApproved
 	appointment.RejectionReason = ""
 	appointment.MeetingLink = body.MeetingLink
 	appointment.AdminNote = body.AdminNote
 
-	if err := initializers.DB.Save(&appointment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating appointment"})
+	err := withDayLock(appointment.AppointmentDate.Time, func(tx *gorm.DB) error {
+		// Asignar asesor, salvo que ya tenga uno y no se pida cambiarlo
+		if body.AdvisorID != "" || appointment.AdvisorID == nil {
+			schedule := availability.Load(tx, appointment.AppointmentDate.Time, id)
+			advisorID, err := schedule.PickAdvisor(body.AdvisorID, appointment.StartMinute, appointment.EndMinute)
+			if err != nil {
+				return err
+			}
+			appointment.AdvisorID = advisorID
+		}
+		return tx.Save(&appointment).Error
+	})
+	if err != nil {
+		respondReservationError(c, err, "Error updating appointment")
 		return
 	}
+	recordAppointmentEvent(c, models.EventApproved, before, appointment, "")
 
 	// Enviar email de aprobación
-	emailService := services.NewEmailService()
 	if err := emailService.SendAppointmentApproved(&appointment); err != nil {
 		// Log error pero no fallar
 		println("Error sending approval email:", err)
@@ -160,37 +138,41 @@ func RejectAppointment(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Rejection reason is required"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Rejection reason is required")})
 		return
 	}
 
 	var appointment models.Appointment
 	if err := initializers.DB.Preload("AppointmentType").Preload("BankAccount").First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
 		return
 	}
 
-	if appointment.Status == models.StatusDone {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot modify completed appointment"})
+	before := appointment
+	if err := appointment.TransitionTo(models.StatusRejected); err != nil {
+		respondTransitionError(c, err)
 		return
 	}
 
-	appointment.Status = models.StatusRejected
 	appointment.RejectionReason = body.Reason
 	appointment.AdminNote = body.AdminNote
+	appointment.CalendarSequence++
 
 	if err := initializers.DB.Save(&appointment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating appointment"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment")})
 		return
 	}
+	recordAppointmentEvent(c, models.EventRejected, before, appointment, body.Reason)
 
-	// Enviar email de rechazo
-	emailService := services.NewEmailService()
-	if err := emailService.SendAppointmentRejected(&appointment, body.Reason); err != nil {
+	// Enviar email de rechazo; solo se rechazan citas pendientes, que no tenían invitación
+	if err := emailService.SendAppointmentRejected(&appointment, body.Reason, false); err != nil {
 		// Log error pero no fallar
 		println("Error sending rejection email:", err)
 	}
 
+	// La franja liberada se ofrece a la lista de espera
+	go processWaitlist()
+
 	c.JSON(http.StatusOK, gin.H{
 		"message":     "Appointment rejected successfully",
 		"appointment": appointment,
@@ -203,21 +185,21 @@ func MarkAppointmentDone(c *gin.Context) {
 
 	var appointment models.Appointment
 	if err := initializers.DB.Preload("BankAccount").First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
 		return
 	}
 
-	if appointment.Status != models.StatusApproved {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Only approved appointments can be marked as done"})
+	before := appointment
+	if err := appointment.TransitionTo(models.StatusDone); err != nil {
+		respondTransitionError(c, err)
 		return
 	}
 
-	appointment.Status = models.StatusDone
-
 	if err := initializers.DB.Save(&appointment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating appointment"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment")})
 		return
 	}
+	recordAppointmentEvent(c, models.EventDone, before, appointment, "")
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":     "Appointment marked as done",
@@ -225,116 +207,110 @@ func MarkAppointmentDone(c *gin.Context) {
 	})
 }
 
+// MarkAppointmentNoShow marca una cita aprobada en la que el cliente no se presentó
+func MarkAppointmentNoShow(c *gin.Context) {
+	id := c.Param("id")
+
+	var appointment models.Appointment
+	if err := initializers.DB.Preload("BankAccount").First(&appointment, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
+		return
+	}
+
+	before := appointment
+	if err := appointment.TransitionTo(models.StatusNoShow); err != nil {
+		respondTransitionError(c, err)
+		return
+	}
+
+	// Solo se sabe que el cliente no se presentó cuando la cita ya empezó
+	if !time.Now().After(appointment.StartsAt(config.Env.BusinessLocation)) {
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, "Cannot mark as no-show before the appointment starts")})
+		return
+	}
+
+	if err := initializers.DB.Save(&appointment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment")})
+		return
+	}
+	recordAppointmentEvent(c, models.EventNoShow, before, appointment, "")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Appointment marked as no-show",
+		"appointment": appointment,
+	})
+}
+
 // MoveAppointment mueve una cita a otra fecha/hora
 func MoveAppointment(c *gin.Context) {
 	id := c.Param("id")
 
 	var body struct {
 		NewDate   string  `json:"newDate" binding:"required"`
-		NewHour   int     `json:"newHour" binding:"required,min=0,max=23"`
+		NewTime   string  `json:"newTime"` // HH:MM
+		NewHour   *int    `json:"newHour"` // Compatibilidad: hora entera 0-23
 		AdminNote *string `json:"adminNote"`
+		Override  bool    `json:"override"` // Ignorar reglas de disponibilidad y ventana de reserva
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data")})
 		return
 	}
 
 	var appointment models.Appointment
-	if err := initializers.DB.Preload("BankAccount").Preload("AppointmentType").First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+	if err := initializers.DB.Preload("BankAccount").Preload("AppointmentType").Preload("MeetingPlatform").First(&appointment, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
 		return
 	}
 
-	if appointment.Status == models.StatusDone {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot move completed appointment"})
+	if !appointment.Status.IsActive() {
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, "Only pending or approved appointments can be moved")})
 		return
 	}
 
 	// Parsear nueva fecha
 	newDate, err := time.Parse("2006-01-02", body.NewDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid date format")})
 		return
 	}
 	newDateOnly := models.NewDateOnly(newDate)
 
-	// Verificar si el nuevo horario está disponible según reglas de disponibilidad
-	dayOfWeek := int(newDateOnly.Time.Weekday())
-
-	// 1. Verificar reglas de día de semana
-	var weekdayRules []models.AvailabilityRule
-	initializers.DB.Where("day_of_week = ?", dayOfWeek).Find(&weekdayRules)
-
-	for _, rule := range weekdayRules {
-		if rule.AllDay {
-			c.JSON(http.StatusConflict, gin.H{"error": "Selected day is blocked"})
-			return
-		}
-		for _, hour := range rule.UnavailableHours {
-			if hour == body.NewHour {
-				c.JSON(http.StatusConflict, gin.H{"error": "Selected hour is not available"})
-				return
-			}
-		}
-	}
-
-	// 2. Verificar reglas de fecha específica
-	var specificDateRules []models.AvailabilityRule
-	specificDate := time.Date(newDateOnly.Time.Year(), newDateOnly.Time.Month(), newDateOnly.Time.Day(), 0, 0, 0, 0, time.UTC)
-	initializers.DB.Where("specific_date = ?", specificDate).Find(&specificDateRules)
-
-	for _, rule := range specificDateRules {
-		if rule.AllDay {
-			c.JSON(http.StatusConflict, gin.H{"error": "Selected date is blocked"})
-			return
-		}
-		for _, hour := range rule.UnavailableHours {
-			if hour == body.NewHour {
-				c.JSON(http.StatusConflict, gin.H{"error": "Selected hour is not available"})
-				return
-			}
-		}
-	}
-
-	// 3. Verificar que no haya otra cita en ese horario
-	var existingAppointment models.Appointment
-	result := initializers.DB.Where("appointment_date = ? AND appointment_hour = ? AND id != ? AND status != ?",
-		newDateOnly, body.NewHour, id, models.StatusRejected).First(&existingAppointment)
-
-	if result.Error == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Time slot already taken"})
+	newStart, err := parseStartMinute(body.NewTime, body.NewHour)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid time")})
 		return
 	}
-
-	// 4. Filtrar horas pasadas si es hoy
-	now := time.Now()
-	isToday := newDateOnly.Time.Year() == now.Year() && newDateOnly.Time.Month() == now.Month() && newDateOnly.Time.Day() == now.Day()
-	if isToday && body.NewHour <= now.Hour() {
-		c.JSON(http.StatusConflict, gin.H{"error": "Cannot move to a past hour"})
+	// La cita conserva su duración al moverse
+	newEnd := newStart + (appointment.EndMinute - appointment.StartMinute)
+	if newEnd > models.MinutesPerDay {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Appointment must end on the same day")})
 		return
 	}
 
-	// Guardar datos anteriores para el email
+	// Guardar datos anteriores para el email y el historial
+	before := appointment
 	oldDate := appointment.AppointmentDate
-	oldHour := appointment.AppointmentHour
-
-	// Actualizar la cita
-	appointment.AppointmentDate = newDateOnly
-	appointment.AppointmentHour = body.NewHour
+	oldStart := appointment.StartMinute
 
 	// Actualizar nota administrativa si se provee
 	if body.AdminNote != nil && *body.AdminNote != "" {
 		appointment.AdminNote = *body.AdminNote
 	}
 
-	if err := initializers.DB.Save(&appointment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating appointment"})
+	err = moveAppointmentTo(&appointment, newDateOnly.Time, newStart, availability.Options{Override: body.Override})
+	if err != nil {
+		respondReservationError(c, err, "Error updating appointment")
 		return
 	}
+	recordAppointmentEvent(c, models.EventMoved, before, appointment, "")
 
 	// Enviar email al cliente notificando el cambio
-	go SendAppointmentMovedEmail(appointment, oldDate.Time, oldHour)
+	go SendAppointmentMovedEmail(appointment, oldDate.Time, oldStart)
+
+	// La franja anterior quedó libre para la lista de espera
+	go processWaitlist()
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":     "Appointment moved successfully",
@@ -348,59 +324,36 @@ func GetCalendarData(c *gin.Context) {
 
 	startDate, err := time.Parse("2006-01", monthStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid month format")})
 		return
 	}
 
 	endDate := startDate.AddDate(0, 1, 0)
 
+	query := initializers.DB.Where("appointment_date >= ? AND appointment_date < ?", startDate, endDate)
+
+	// Filtro opcional por asesor ("unassigned" para citas sin asesor)
+	if advisorID := c.Query("advisorId"); advisorID == "unassigned" {
+		query = query.Where("advisor_id IS NULL")
+	} else if advisorID != "" {
+		query = query.Where("advisor_id = ?", advisorID)
+	}
+
 	var appointments []models.Appointment
-	initializers.DB.Where("appointment_date >= ? AND appointment_date < ?", startDate, endDate).
-		Preload("AppointmentType").
+	query.Preload("AppointmentType").
 		Preload("BankAccount").
 		Preload("MeetingPlatform").
+		Preload("Advisor").
 		Find(&appointments)
 
 	// Agrupar por fecha
 	calendarData := make(map[string][]gin.H)
 
-	// Mapa de traducción de días de la semana
-	daysES := map[string]string{
-		"Monday":    "Lunes",
-		"Tuesday":   "Martes",
-		"Wednesday": "Miércoles",
-		"Thursday":  "Jueves",
-		"Friday":    "Viernes",
-		"Saturday":  "Sábado",
-		"Sunday":    "Domingo",
-	}
-
-	// Mapa de traducción de meses
-	monthsES := map[string]string{
-		"January":   "Enero",
-		"February":  "Febrero",
-		"March":     "Marzo",
-		"April":     "Abril",
-		"May":       "Mayo",
-		"June":      "Junio",
-		"July":      "Julio",
-		"August":    "Agosto",
-		"September": "Septiembre",
-		"October":   "Octubre",
-		"November":  "Noviembre",
-		"December":  "Diciembre",
-	}
-
 	for _, app := range appointments {
 		dateKey := app.AppointmentDate.Time.Format("2006-01-02")
 
-		// Formatear fecha en español
-		dayName := app.AppointmentDate.Time.Format("Monday")
-		monthName := app.AppointmentDate.Time.Format("January")
-		day := app.AppointmentDate.Time.Format("2")
-		year := app.AppointmentDate.Time.Format("2006")
-
-		formattedDate := daysES[dayName] + ", " + day + " de " + monthsES[monthName] + " de " + year
+		// El panel del admin usa el idioma del negocio
+		formattedDate := i18n.FormatDate(i18n.Default, app.AppointmentDate.Time)
 
 		// Si BankAccount es nil pero BankTransfer tiene un UUID, intentar cargar el banco
 		bankAccount := app.BankAccount
@@ -421,6 +374,11 @@ func GetCalendarData(c *gin.Context) {
 			platformName = app.MeetingPlatform.Name
 		}
 
+		var advisorName string
+		if app.Advisor != nil {
+			advisorName = app.Advisor.Name
+		}
+
 		calendarData[dateKey] = append(calendarData[dateKey], gin.H{
 			"id":                app.ID,
 			"shortID":           app.ShortID,
@@ -429,7 +387,9 @@ func GetCalendarData(c *gin.Context) {
 			"email":             app.Email,
 			"phoneNumber":       app.PhoneNumber,
 			"date":              formattedDate,
-			"hour":              app.AppointmentHour,
+			"hour":              app.StartMinute / 60,
+			"startTime":         app.StartTime(),
+			"endTime":           app.EndTime(),
 			"type":              app.AppointmentType.Name,
 			"status":            app.Status,
 			"bankTransfer":      app.BankTransfer,
@@ -441,6 +401,9 @@ func GetCalendarData(c *gin.Context) {
 			"meetingPlatform":   platformName,
 			"meetingPlatformId": app.MeetingPlatformID,
 			"createdByAdmin":    app.CreatedByAdmin,
+			"override":          app.BookedWithOverride,
+			"advisorId":         app.AdvisorID,
+			"advisor":           advisorName,
 		})
 	}
 
@@ -463,21 +426,33 @@ func GetAllAppointmentTypes(c *gin.Context) {
 
 func CreateAppointmentType(c *gin.Context) {
 	var body struct {
-		Name string `json:"name" binding:"required"`
+		Name            string `json:"name" binding:"required"`
+		DurationMinutes int    `json:"durationMinutes" binding:"omitempty,min=5,max=720"`
+		Capacity        *int   `json:"capacity" binding:"omitempty,min=1"`
+		MinLeadMinutes  *int   `json:"minLeadMinutes" binding:"omitempty,min=0"`
+		MaxHorizonDays  *int   `json:"maxHorizonDays" binding:"omitempty,min=0"`
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Name is required")})
 		return
 	}
 
+	if body.DurationMinutes == 0 {
+		body.DurationMinutes = 60
+	}
+
 	appointmentType := models.AppointmentType{
-		Name:    body.Name,
-		Visible: true,
+		Name:            body.Name,
+		Visible:         true,
+		DurationMinutes: body.DurationMinutes,
+		Capacity:        body.Capacity,
+		MinLeadMinutes:  body.MinLeadMinutes,
+		MaxHorizonDays:  body.MaxHorizonDays,
 	}
 
 	if err := initializers.DB.Create(&appointmentType).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating appointment type"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error creating appointment type")})
 		return
 	}
 
@@ -494,20 +469,20 @@ func UpdateAppointmentTypeVisibility(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data")})
 		return
 	}
 
 	var appointmentType models.AppointmentType
 	if err := initializers.DB.First(&appointmentType, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment type not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
 		return
 	}
 
 	appointmentType.Visible = body.Visible
 
 	if err := initializers.DB.Save(&appointmentType).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating appointment type"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment type")})
 		return
 	}
 
@@ -516,14 +491,107 @@ func UpdateAppointmentTypeVisibility(c *gin.Context) {
 	})
 }
 
-// SendAppointmentMovedEmail envía email cuando una cita es movida
-func SendAppointmentMovedEmail(appointment models.Appointment, oldDate time.Time, oldHour int) {
-	emailService := services.NewEmailService()
+// UpdateAppointmentTypeDuration cambia la duración de un tipo de cita.
+// Las citas ya agendadas conservan la duración con la que fueron creadas.
+func UpdateAppointmentTypeDuration(c *gin.Context) {
+	id := c.Param("id")
 
-	oldDateFormatted := formatSpanishDate(oldDate)
-	newDateFormatted := formatSpanishDate(appointment.AppointmentDate.Time)
+	var body struct {
+		DurationMinutes int `json:"durationMinutes" binding:"required,min=5,max=720"`
+	}
 
-	if err := emailService.SendAppointmentMoved(&appointment, oldDateFormatted, newDateFormatted, oldHour, appointment.AppointmentHour); err != nil {
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid duration")})
+		return
+	}
+
+	var appointmentType models.AppointmentType
+	if err := initializers.DB.First(&appointmentType, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
+		return
+	}
+
+	appointmentType.DurationMinutes = body.DurationMinutes
+
+	if err := initializers.DB.Save(&appointmentType).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment type")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"appointmentType": appointmentType,
+	})
+}
+
+// UpdateAppointmentTypeCapacity cambia el máximo de citas simultáneas de un tipo.
+// Enviar capacity null elimina el límite propio del tipo.
+func UpdateAppointmentTypeCapacity(c *gin.Context) {
+	id := c.Param("id")
+
+	var body struct {
+		Capacity *int `json:"capacity" binding:"omitempty,min=1"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid capacity")})
+		return
+	}
+
+	var appointmentType models.AppointmentType
+	if err := initializers.DB.First(&appointmentType, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
+		return
+	}
+
+	appointmentType.Capacity = body.Capacity
+
+	if err := initializers.DB.Save(&appointmentType).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment type")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"appointmentType": appointmentType,
+	})
+}
+
+// UpdateAppointmentTypeBookingWindow cambia la antelación mínima y el horizonte máximo
+// de un tipo de cita; un valor null vuelve a usar el valor global
+func UpdateAppointmentTypeBookingWindow(c *gin.Context) {
+	id := c.Param("id")
+
+	var body struct {
+		MinLeadMinutes *int `json:"minLeadMinutes" binding:"omitempty,min=0"`
+		MaxHorizonDays *int `json:"maxHorizonDays" binding:"omitempty,min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid booking window")})
+		return
+	}
+
+	var appointmentType models.AppointmentType
+	if err := initializers.DB.First(&appointmentType, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
+		return
+	}
+
+	appointmentType.MinLeadMinutes = body.MinLeadMinutes
+	appointmentType.MaxHorizonDays = body.MaxHorizonDays
+
+	if err := initializers.DB.Save(&appointmentType).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment type")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"appointmentType": appointmentType,
+	})
+}
+
+// SendAppointmentMovedEmail envía email cuando una cita es movida
+func SendAppointmentMovedEmail(appointment models.Appointment, oldDate time.Time, oldStart int) {
+	if err := emailService.SendAppointmentMoved(&appointment, oldDate, oldStart); err != nil {
 		// Log error but don't fail the request
 		println("Error sending appointment moved email:", err.Error())
 	}
@@ -541,25 +609,27 @@ func UpdateAppointmentDetails(c *gin.Context) {
 		MeetingLink       *string `json:"meetingLink"`
 		AdminNote         *string `json:"adminNote"`
 		MeetingPlatformID *string `json:"meetingPlatformId"`
+		Locale            *string `json:"locale"`
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data")})
 		return
 	}
 
 	var appointment models.Appointment
 	if err := initializers.DB.Preload("AppointmentType").Preload("MeetingPlatform").First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
 		return
 	}
 
 	// No permitir editar citas completadas
 	if appointment.Status == models.StatusDone {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot edit completed appointments"})
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "Cannot edit completed appointments")})
 		return
 	}
 
+	before := appointment
 	if body.FirstName != nil {
 		appointment.FirstName = *body.FirstName
 	}
@@ -585,11 +655,20 @@ func UpdateAppointmentDetails(c *gin.Context) {
 			appointment.MeetingPlatformID = &platformUUID
 		}
 	}
+	if body.Locale != nil {
+		locale, ok := i18n.Parse(*body.Locale)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid locale")})
+			return
+		}
+		appointment.Locale = locale
+	}
 
 	if err := initializers.DB.Save(&appointment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating appointment"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment")})
 		return
 	}
+	recordAppointmentEvent(c, models.EventEdited, before, appointment, "")
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":     "Appointment updated successfully",
@@ -606,34 +685,36 @@ func UpdateAppointmentPlatform(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Meeting platform ID is required"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Meeting platform ID is required")})
 		return
 	}
 
 	platformID, err := uuid.Parse(body.MeetingPlatformID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid platform ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid platform ID")})
 		return
 	}
 
 	// Verificar que la plataforma existe
 	var platform models.MeetingPlatform
 	if err := initializers.DB.First(&platform, "id = ?", platformID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Meeting platform not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Meeting platform not found")})
 		return
 	}
 
 	var appointment models.Appointment
 	if err := initializers.DB.Preload("AppointmentType").Preload("MeetingPlatform").First(&appointment, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
 		return
 	}
 
+	before := appointment
 	appointment.MeetingPlatformID = &platformID
 	if err := initializers.DB.Save(&appointment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating appointment"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment")})
 		return
 	}
+	recordAppointmentEvent(c, models.EventPlatformChanged, before, appointment, "")
 
 	// Recargar con la relación
 	initializers.DB.Preload("AppointmentType").Preload("MeetingPlatform").First(&appointment, "id = ?", id)
@@ -652,56 +733,75 @@ func AdminCreateAppointment(c *gin.Context) {
 		Email             string `json:"email"`
 		PhoneNumber       string `json:"phoneNumber"`
 		AppointmentDate   string `json:"appointmentDate" binding:"required"`
-		AppointmentHour   int    `json:"appointmentHour" binding:"required,min=0,max=23"`
+		AppointmentTime   string `json:"appointmentTime"` // HH:MM
+		AppointmentHour   *int   `json:"appointmentHour"` // Compatibilidad: hora entera 0-23
 		AppointmentTypeID uint   `json:"appointmentTypeId" binding:"required"`
 		MeetingLink       string `json:"meetingLink"`
 		AdminNote         string `json:"adminNote"`
 		MeetingPlatformID string `json:"meetingPlatformId"`
+		AdvisorID         string `json:"advisorId"` // Opcional: si no se indica se asigna el primer asesor libre
+		Override          bool   `json:"override"`  // Ignorar reglas de disponibilidad y ventana de reserva
+		Locale            string `json:"locale"`    // Idioma de los emails del cliente (es, en, it)
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data: " + err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data: %s", err.Error())})
 		return
 	}
 
 	// Parsear fecha
 	parsedDate, err := time.Parse("2006-01-02", body.AppointmentDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid date format")})
 		return
 	}
 	appointmentDate := models.NewDateOnly(parsedDate)
 
+	// Sin idioma se usa el del negocio; uno no soportado se rechaza como al editar la cita
+	locale := i18n.Default
+	if body.Locale != "" {
+		parsed, ok := i18n.Parse(body.Locale)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid locale")})
+			return
+		}
+		locale = parsed
+	}
+
 	// Verificar tipo de cita
 	var appointmentType models.AppointmentType
 	if err := initializers.DB.First(&appointmentType, body.AppointmentTypeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment type not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
 		return
 	}
 
-	// Verificar disponibilidad
-	var existingAppointment models.Appointment
-	result := initializers.DB.Where("appointment_date = ? AND appointment_hour = ? AND status != ?",
-		appointmentDate, body.AppointmentHour, models.StatusRejected).First(&existingAppointment)
-
-	if result.Error == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Time slot not available"})
+	startMinute, err := parseStartMinute(body.AppointmentTime, body.AppointmentHour)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid time")})
+		return
+	}
+	endMinute := startMinute + appointmentType.DurationMinutes
+	if endMinute > models.MinutesPerDay {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Appointment must end on the same day")})
 		return
 	}
 
 	appointment := models.Appointment{
-		FirstName:         body.FirstName,
-		LastName:          body.LastName,
-		Email:             body.Email,
-		PhoneNumber:       regexp.MustCompile(`\D`).ReplaceAllString(body.PhoneNumber, ""),
-		AppointmentDate:   appointmentDate,
-		AppointmentHour:   body.AppointmentHour,
-		AppointmentTypeID: body.AppointmentTypeID,
-		MeetingLink:       body.MeetingLink,
-		AdminNote:         body.AdminNote,
-		Status:            models.StatusApproved,
-		CreatedByAdmin:    true,
-		ReceiptPath:       "", // No receipt for admin-created appointments
+		FirstName:          body.FirstName,
+		LastName:           body.LastName,
+		Email:              body.Email,
+		PhoneNumber:        regexp.MustCompile(`\D`).ReplaceAllString(body.PhoneNumber, ""),
+		AppointmentDate:    appointmentDate,
+		StartMinute:        startMinute,
+		EndMinute:          endMinute,
+		AppointmentTypeID:  body.AppointmentTypeID,
+		MeetingLink:        body.MeetingLink,
+		AdminNote:          body.AdminNote,
+		Status:             models.StatusApproved,
+		CreatedByAdmin:     true,
+		BookedWithOverride: body.Override,
+		ReceiptPath:        "", // No receipt for admin-created appointments
+		Locale:             locale,
 	}
 
 	// Asignar plataforma si se proporcionó
@@ -712,10 +812,27 @@ func AdminCreateAppointment(c *gin.Context) {
 		}
 	}
 
-	if err := initializers.DB.Create(&appointment).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating appointment"})
+	// Verificar reglas, horario y cupo, asignar asesor y crear con la agenda del día bloqueada
+	err = withDayLock(appointmentDate.Time, func(tx *gorm.DB) error {
+		schedule := availability.Load(tx, appointmentDate.Time, "")
+		if err := schedule.IsSlotAvailable(startMinute, endMinute, appointmentType, availability.Options{Override: body.Override}); err != nil {
+			return err
+		}
+
+		advisorID, err := schedule.PickAdvisor(body.AdvisorID, startMinute, endMinute)
+		if err != nil {
+			return err
+		}
+		appointment.AdvisorID = advisorID
+		appointment.SlotCapacity = schedule.Capacity()
+
+		return tx.Create(&appointment).Error
+	})
+	if err != nil {
+		respondReservationError(c, err, "Error creating appointment")
 		return
 	}
+	recordAppointmentEvent(c, models.EventCreated, models.Appointment{}, appointment, "")
 
 	// Cargar relaciones
 	initializers.DB.Preload("AppointmentType").First(&appointment, "id = ?", appointment.ID)
@@ -729,11 +846,11 @@ func AdminCreateAppointment(c *gin.Context) {
 // GetDashboardStats obtiene estadísticas para el dashboard
 func GetDashboardStats(c *gin.Context) {
 	// Mes actual o el especificado
-	monthStr := c.DefaultQuery("month", time.Now().Format("2006-01"))
+	monthStr := c.DefaultQuery("month", config.Env.BusinessNow().Format("2006-01"))
 
 	startDate, err := time.Parse("2006-01", monthStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month format"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid month format")})
 		return
 	}
 	endDate := startDate.AddDate(0, 1, 0)
@@ -743,11 +860,13 @@ func GetDashboardStats(c *gin.Context) {
 	initializers.DB.Where("appointment_date >= ? AND appointment_date < ?", startDate, endDate).Find(&appointments)
 
 	stats := gin.H{
-		"pending":  0,
-		"approved": 0,
-		"rejected": 0,
-		"done":     0,
-		"total":    len(appointments),
+		"pending":   0,
+		"approved":  0,
+		"rejected":  0,
+		"done":      0,
+		"cancelled": 0,
+		"no_show":   0,
+		"total":     len(appointments),
 	}
 
 	// Agrupar por día para los gráficos
@@ -763,6 +882,10 @@ func GetDashboardStats(c *gin.Context) {
 			stats["rejected"] = stats["rejected"].(int) + 1
 		case models.StatusDone:
 			stats["done"] = stats["done"].(int) + 1
+		case models.StatusCancelled:
+			stats["cancelled"] = stats["cancelled"].(int) + 1
+		case models.StatusNoShow:
+			stats["no_show"] = stats["no_show"].(int) + 1
 		}
 
 		dateKey := apt.AppointmentDate.Time.Format("2006-01-02")
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// El idioma se valida antes de tocar la base de datos, así que no hace falta TEST_DATABASE_DSN
+func TestAdminCreateAppointmentRejectsInvalidLocale(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/admin/appointments", AdminCreateAppointment)
+
+	body := `{"firstName":"Ana","lastName":"Pérez","appointmentDate":"2030-05-06","appointmentTime":"10:00","appointmentTypeId":1,"locale":"fr"}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/appointments", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "Invalid locale") {
+		t.Errorf("body = %s, want the invalid locale error", w.Body.String())
+	}
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMarkAppointmentNoShowOnlyAfterStart(t *testing.T) {
+	setupTestDB(t)
+	appointmentType := testAppointmentType(t)
+	future := testBookingDate(t, 4)
+	past := time.Date(2020, time.January, 8, 0, 0, 0, 0, time.UTC)
+	t.Cleanup(func() {
+		initializers.DB.Unscoped().Where("appointment_date = ?", past).Delete(&models.Appointment{})
+	})
+
+	router := gin.New()
+	router.POST("/admin/appointments/:id/no-show", MarkAppointmentNoShow)
+
+	tests := []struct {
+		name       string
+		date       time.Time
+		wantCode   int
+		wantStatus models.AppointmentStatus
+	}{
+		{name: "not started yet", date: future, wantCode: http.StatusConflict, wantStatus: models.StatusApproved},
+		{name: "already started", date: past, wantCode: http.StatusOK, wantStatus: models.StatusNoShow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appointment := models.Appointment{
+				FirstName:         "Cliente",
+				LastName:          "Ausente",
+				Email:             "ausente@ktravel.test",
+				PhoneNumber:       "8095551234",
+				AppointmentDate:   models.NewDateOnly(tt.date),
+				StartMinute:       600,
+				EndMinute:         660,
+				AppointmentTypeID: appointmentType.ID,
+				BankTransfer:      models.BankPopular,
+				Status:            models.StatusApproved,
+				SlotCapacity:      1,
+			}
+			if err := initializers.DB.Create(&appointment).Error; err != nil {
+				t.Fatalf("create appointment: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/appointments/"+appointment.ID.String()+"/no-show", nil))
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+
+			var stored models.Appointment
+			initializers.DB.First(&stored, "id = ?", appointment.ID)
+			if stored.Status != tt.wantStatus {
+				t.Fatalf("appointment status = %s, want %s", stored.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+
+	"github.com/gin-gonic/gin"
+	uuid "github.com/google/uuid"
+)
+
+// GetAdvisors lista todos los asesores
+func GetAdvisors(c *gin.Context) {
+	var advisors []models.Advisor
+	initializers.DB.Order("name ASC").Find(&advisors)
+
+	c.JSON(http.StatusOK, gin.H{
+		"advisors": advisors,
+	})
+}
+
+// CreateAdvisor crea un asesor, opcionalmente vinculado a un usuario del panel
+func CreateAdvisor(c *gin.Context) {
+	var body struct {
+		Name   string     `json:"name" binding:"required"`
+		Email  string     `json:"email"`
+		UserID *uuid.UUID `json:"userId"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Name is required")})
+		return
+	}
+
+	if body.UserID != nil {
+		var user models.User
+		if err := initializers.DB.First(&user, "id = ?", *body.UserID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "User not found")})
+			return
+		}
+	}
+
+	advisor := models.Advisor{
+		Name:     body.Name,
+		Email:    body.Email,
+		UserID:   body.UserID,
+		IsActive: true,
+	}
+
+	if err := initializers.DB.Create(&advisor).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error creating advisor")})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"advisor": advisor,
+	})
+}
+
+// UpdateAdvisor actualiza nombre, email, usuario vinculado o estado activo
+func UpdateAdvisor(c *gin.Context) {
+	id := c.Param("id")
+
+	var body struct {
+		Name     *string    `json:"name"`
+		Email    *string    `json:"email"`
+		UserID   *uuid.UUID `json:"userId"`
+		IsActive *bool      `json:"isActive"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data")})
+		return
+	}
+
+	var advisor models.Advisor
+	if err := initializers.DB.First(&advisor, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Advisor not found")})
+		return
+	}
+
+	if body.Name != nil {
+		advisor.Name = *body.Name
+	}
+	if body.Email != nil {
+		advisor.Email = *body.Email
+	}
+	if body.UserID != nil {
+		var user models.User
+		if err := initializers.DB.First(&user, "id = ?", *body.UserID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "User not found")})
+			return
+		}
+		advisor.UserID = body.UserID
+	}
+	if body.IsActive != nil {
+		advisor.IsActive = *body.IsActive
+	}
+
+	if err := initializers.DB.Save(&advisor).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating advisor")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"advisor": advisor,
+	})
+}
+
+// DeleteAdvisor desactiva un asesor. Se conserva el registro para no perder
+// la asignación de sus citas anteriores.
+func DeleteAdvisor(c *gin.Context) {
+	id := c.Param("id")
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid ID")})
+		return
+	}
+
+	var advisor models.Advisor
+	if err := initializers.DB.First(&advisor, "id = ?", parsedID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Advisor not found")})
+		return
+	}
+
+	advisor.IsActive = false
+	if err := initializers.DB.Save(&advisor).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error deactivating advisor")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Advisor deactivated",
+	})
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bookingRequest arma el formulario multipart de POST /appointments
+func bookingRequest(t *testing.T, date time.Time, startTime string, appointmentTypeID uint, n int) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	fields := map[string]string{
+		"firstName":         "Cliente",
+		"lastName":          strconv.Itoa(n),
+		"email":             fmt.Sprintf("cliente%d@ktravel.test", n),
+		"phoneNumber":       "8095551234",
+		"appointmentDate":   date.Format("2006-01-02"),
+		"appointmentTime":   startTime,
+		"appointmentTypeID": strconv.FormatUint(uint64(appointmentTypeID), 10),
+		"bankTransfer":      string(models.BankPopular),
+	}
+	for name, value := range fields {
+		form.WriteField(name, value)
+	}
+	receipt, _ := form.CreateFormFile("receipt", "receipt.png")
+	receipt.Write([]byte("png"))
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/appointments", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+func TestCreateAppointmentConcurrentCapacity(t *testing.T) {
+	setupTestDB(t)
+	config.Env.SlotCapacity = 2
+	appointmentType := testAppointmentType(t)
+	date := testBookingDate(t, 0)
+
+	router := gin.New()
+	router.POST("/appointments", CreateAppointment)
+
+	const requests = 10
+	codes := make([]int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, bookingRequest(t, date, "10:00", appointmentType.ID, i))
+			codes[i] = w.Code
+		}(i)
+	}
+	wg.Wait()
+
+	created, conflicts := 0, 0
+	for _, code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+			conflicts++
+		default:
+			t.Errorf("unexpected status %d", code)
+		}
+	}
+	if created != config.Env.SlotCapacity || conflicts != requests-config.Env.SlotCapacity {
+		t.Fatalf("got %d created and %d conflicts, want %d and %d", created, conflicts, config.Env.SlotCapacity, requests-config.Env.SlotCapacity)
+	}
+
+	var stored int64
+	initializers.DB.Model(&models.Appointment{}).Where("appointment_date = ?", date).Count(&stored)
+	if stored != int64(config.Env.SlotCapacity) {
+		t.Fatalf("stored %d appointments, want %d", stored, config.Env.SlotCapacity)
+	}
+}
+
+// La base de datos rechaza la sobreventa aunque las escrituras no pasen por withDayLock
+func TestAppointmentCapacityTrigger(t *testing.T) {
+	setupTestDB(t)
+	appointmentType := testAppointmentType(t)
+	date := testBookingDate(t, 1)
+
+	const writers = 8
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = initializers.DB.Create(&models.Appointment{
+				FirstName:         "Cliente",
+				LastName:          strconv.Itoa(i),
+				Email:             "cliente@ktravel.test",
+				PhoneNumber:       "8095551234",
+				AppointmentDate:   models.NewDateOnly(date),
+				StartMinute:       600,
+				EndMinute:         660,
+				AppointmentTypeID: appointmentType.ID,
+				BankTransfer:      models.BankPopular,
+				Status:            models.StatusPending,
+				SlotCapacity:      3,
+			}).Error
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !isSlotConflict(err):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if created != 3 {
+		t.Fatalf("created %d appointments, want 3", created)
+	}
+
+	// Una cita que empieza dentro de la franja llena también se rechaza
+	err := initializers.DB.Create(&models.Appointment{
+		FirstName: "Cliente", LastName: "Solapada", Email: "cliente@ktravel.test", PhoneNumber: "8095551234",
+		AppointmentDate: models.NewDateOnly(date), StartMinute: 630, EndMinute: 690,
+		AppointmentTypeID: appointmentType.ID, BankTransfer: models.BankPopular, SlotCapacity: 3,
+	}).Error
+	if !isSlotConflict(err) {
+		t.Fatalf("overlapping appointment: got %v, want slot conflict", err)
+	}
+}
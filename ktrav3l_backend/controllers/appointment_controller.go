@@ -1,15 +1,19 @@
 package controllers
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
 	"pixelbrew-llc/ktrav3l_backend/initializers"
 	"pixelbrew-llc/ktrav3l_backend/models"
 	"pixelbrew-llc/ktrav3l_backend/services"
+	"pixelbrew-llc/ktrav3l_backend/services/availability"
+	"pixelbrew-llc/ktrav3l_backend/services/ical"
 	"regexp"
 	"strconv"
 	"strings"
@@ -17,6 +21,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // CreateAppointment crea una nueva cita
@@ -24,7 +29,7 @@ func CreateAppointment(c *gin.Context) {
 	// Parsear form multipart
 	err := c.Request.ParseMultipartForm(5 << 20) // 5 MB max
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Error parsing form data"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Error parsing form data")})
 		return
 	}
 
@@ -34,9 +39,12 @@ func CreateAppointment(c *gin.Context) {
 	email := c.PostForm("email")
 	phoneNumber := c.PostForm("phoneNumber")
 	appointmentDateStr := c.PostForm("appointmentDate")
+	appointmentTimeStr := c.PostForm("appointmentTime")
 	appointmentHourStr := c.PostForm("appointmentHour")
 	appointmentTypeIDStr := c.PostForm("appointmentTypeID")
 	bankTransfer := c.PostForm("bankTransfer")
+	holdToken := c.PostForm("holdToken") // Opcional: reserva temporal obtenida en POST /appointments/holds
+	locale := bookingLocale(c, c.PostForm("locale"))
 
 	// Parsear BankAccountID si viene como UUID
 	var bankAccountID *uuid.UUID
@@ -49,101 +57,84 @@ func CreateAppointment(c *gin.Context) {
 
 	// Validaciones básicas
 	if firstName == "" || lastName == "" || email == "" || phoneNumber == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Missing required fields")})
 		return
 	}
 
 	// Validar que el teléfono tenga 10 dígitos (ignorar formato)
 	cleanPhone := regexp.MustCompile(`\D`).ReplaceAllString(phoneNumber, "")
 	if len(cleanPhone) != 10 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "El teléfono debe tener 10 dígitos"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "El teléfono debe tener 10 dígitos")})
 		return
 	}
 
 	// Parsear fecha y hora
 	parsedDate, err := time.Parse("2006-01-02", appointmentDateStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid date format")})
 		return
 	}
 	appointmentDate := models.NewDateOnly(parsedDate)
 
-	appointmentHour, err := strconv.Atoi(appointmentHourStr)
-	if err != nil || appointmentHour < 0 || appointmentHour > 23 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hour"})
+	var appointmentHour *int
+	if hour, err := strconv.Atoi(appointmentHourStr); err == nil {
+		appointmentHour = &hour
+	}
+	startMinute, err := parseStartMinute(appointmentTimeStr, appointmentHour)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid hour")})
 		return
 	}
 
 	appointmentTypeID, err := strconv.ParseUint(appointmentTypeIDStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid appointment type ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid appointment type ID")})
 		return
 	}
 
 	// Verificar que el tipo de cita existe y está visible
 	var appointmentType models.AppointmentType
 	if err := initializers.DB.First(&appointmentType, appointmentTypeID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment type not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
 		return
 	}
 
 	if !appointmentType.Visible {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Appointment type not available"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Appointment type not available")})
 		return
 	}
 
-	// Verificar disponibilidad (no debe haber otra cita en la misma fecha/hora)
-	var existingAppointment models.Appointment
-	result := initializers.DB.Where("appointment_date = ? AND appointment_hour = ? AND status != ?",
-		appointmentDate, appointmentHour, models.StatusRejected).First(&existingAppointment)
-
-	if result.Error == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Time slot not available"})
+	endMinute := startMinute + appointmentType.DurationMinutes
+	if endMinute > models.MinutesPerDay {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Appointment must end on the same day")})
 		return
 	}
 
-	// Verificar reglas de disponibilidad con el nuevo sistema
-	dayOfWeek := int(appointmentDate.Time.Weekday())
-
-	// Verificar reglas de día de semana
-	var weekdayRules []models.AvailabilityRule
-	initializers.DB.Where("day_of_week = ?", dayOfWeek).Find(&weekdayRules)
-
-	for _, rule := range weekdayRules {
-		if rule.AllDay {
-			c.JSON(http.StatusConflict, gin.H{"error": "This day is blocked"})
+	// Si el cliente reservó la franja antes de subir el comprobante, validar su reserva temporal
+	if holdToken != "" {
+		var hold models.SlotHold
+		if err := initializers.DB.Where("token = ? AND expires_at > ?", holdToken, time.Now()).First(&hold).Error; err != nil {
+			c.JSON(http.StatusGone, gin.H{"error": translate(c, "Hold expired or not found")})
 			return
 		}
-		for _, blockedHour := range rule.UnavailableHours {
-			if appointmentHour == blockedHour {
-				c.JSON(http.StatusConflict, gin.H{"error": "This time slot is blocked"})
-				return
-			}
+		if !hold.AppointmentDate.Time.Equal(appointmentDate.Time) || hold.StartMinute != startMinute || hold.AppointmentTypeID != appointmentType.ID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Hold does not match the requested slot")})
+			return
 		}
 	}
 
-	// Verificar reglas de fecha específica
-	var specificDateRules []models.AvailabilityRule
-	specificDate := time.Date(appointmentDate.Time.Year(), appointmentDate.Time.Month(), appointmentDate.Time.Day(), 0, 0, 0, 0, time.UTC)
-	initializers.DB.Where("specific_date = ?", specificDate).Find(&specificDateRules)
-
-	for _, rule := range specificDateRules {
-		if rule.AllDay {
-			c.JSON(http.StatusConflict, gin.H{"error": "This date is blocked"})
-			return
-		}
-		for _, blockedHour := range rule.UnavailableHours {
-			if appointmentHour == blockedHour {
-				c.JSON(http.StatusConflict, gin.H{"error": "This time slot is blocked"})
-				return
-			}
-		}
+	// Verificación previa de reglas, horario y cupo antes de guardar el archivo
+	// (la reserva propia no ocupa lugar); se repite dentro de la transacción al crear la cita
+	schedule := availability.Load(initializers.DB, appointmentDate.Time, "").WithoutHold(holdToken)
+	if err := schedule.IsSlotAvailable(startMinute, endMinute, appointmentType, availability.Options{}); err != nil {
+		respondReservationError(c, err, "Error checking availability")
+		return
 	}
 
 	// Manejar archivo de comprobante
 	file, header, err := c.Request.FormFile("receipt")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Receipt file is required"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Receipt file is required")})
 		return
 	}
 	defer file.Close()
@@ -151,14 +142,14 @@ func CreateAppointment(c *gin.Context) {
 	// Validar tipo de archivo (solo imágenes y PDF)
 	ext := strings.ToLower(filepath.Ext(header.Filename))
 	if ext != ".jpg" && ext != ".jpeg" && ext != ".png" && ext != ".pdf" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file type. Only JPG, PNG, and PDF allowed"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid file type. Only JPG, PNG, and PDF allowed")})
 		return
 	}
 
 	// Crear directorio de uploads si no existe
 	uploadsPath := config.Env.UploadsPath
 	if err := os.MkdirAll(uploadsPath, 0755); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating uploads directory"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error creating uploads directory")})
 		return
 	}
 
@@ -169,14 +160,14 @@ func CreateAppointment(c *gin.Context) {
 	// Guardar archivo
 	out, err := os.Create(filepath)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error saving file"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error saving file")})
 		return
 	}
 	defer out.Close()
 
 	_, err = io.Copy(out, file)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error saving file"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error saving file")})
 		return
 	}
 
@@ -189,26 +180,51 @@ func CreateAppointment(c *gin.Context) {
 		Email:             email,
 		PhoneNumber:       cleanPhone,
 		AppointmentDate:   appointmentDate,
-		AppointmentHour:   appointmentHour,
+		StartMinute:       startMinute,
+		EndMinute:         endMinute,
 		AppointmentTypeID: uint(appointmentTypeID),
 		BankAccountID:     bankAccountID,
 		BankTransfer:      models.BankType(bankTransfer),
 		ReceiptPath:       filepath,
 		Status:            models.StatusPending,
+		Locale:            locale,
 	}
 
-	if err := initializers.DB.Create(&appointment).Error; err != nil {
+	// Crear la cita con la agenda del día bloqueada para que dos reservas
+	// simultáneas no puedan tomar el mismo lugar
+	err = withDayLock(appointmentDate.Time, func(tx *gorm.DB) error {
+		schedule := availability.Load(tx, appointmentDate.Time, "").WithoutHold(holdToken)
+		if err := schedule.IsSlotAvailable(startMinute, endMinute, appointmentType, availability.Options{}); err != nil {
+			return err
+		}
+		appointment.SlotCapacity = schedule.Capacity()
+		if err := tx.Create(&appointment).Error; err != nil {
+			return err
+		}
+		// La reserva temporal se consume al crear la cita; si venía de la lista de espera,
+		// el cliente tomó la franja ofrecida
+		if holdToken != "" {
+			if err := tx.Unscoped().Where("token = ?", holdToken).Delete(&models.SlotHold{}).Error; err != nil {
+				return err
+			}
+			return tx.Model(&models.WaitlistEntry{}).
+				Where("claim_token = ? AND status = ?", holdToken, models.WaitlistNotified).
+				Update("status", models.WaitlistClaimed).Error
+		}
+		return nil
+	})
+	if err != nil {
 		// Si hay error, eliminar archivo subido
 		os.Remove(filepath)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating appointment"})
+		respondReservationError(c, err, "Error creating appointment")
 		return
 	}
+	recordAppointmentEvent(c, models.EventCreated, models.Appointment{}, appointment, "")
 
 	// Cargar el tipo de cita para el email
 	initializers.DB.First(&appointment.AppointmentType, appointment.AppointmentTypeID)
 
 	// Enviar email de confirmación
-	emailService := services.NewEmailService()
 	if err := emailService.SendAppointmentConfirmation(&appointment); err != nil {
 		// Log error pero no fallar la request
 		fmt.Println("Error sending confirmation email:", err)
@@ -231,183 +247,371 @@ func CreateAppointment(c *gin.Context) {
 
 // GetAppointmentByShortID obtiene una cita por su código corto
 func GetAppointmentByShortID(c *gin.Context) {
-	shortID := c.Param("shortID")
-
-	var appointment models.Appointment
-	if err := initializers.DB.Preload("AppointmentType").Where("short_id = ?", shortID).First(&appointment).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+	appointment, ok := findAppointmentByCode(c, initializers.DB.Preload("AppointmentType").Preload("MeetingPlatform"))
+	if !ok {
 		return
 	}
 
-	// Formato del teléfono para mostrar: +1 (###) ###-####
-	phone := appointment.PhoneNumber
-	var phoneFormatted string
-	if len(phone) == 10 {
-		phoneFormatted = fmt.Sprintf("+1(%s) %s-%s", phone[0:3], phone[3:6], phone[6:10])
+	response := gin.H{
+		"id":                appointment.ID,
+		"shortID":           appointment.ShortID,
+		"firstName":         appointment.FirstName,
+		"appointmentDate":   appointment.AppointmentDate,
+		"appointmentHour":   appointment.StartMinute / 60,
+		"startTime":         appointment.StartTime(),
+		"endTime":           appointment.EndTime(),
+		"startsAt":          appointment.StartsAt(config.Env.BusinessLocation).Format(time.RFC3339),
+		"endsAt":            appointment.EndsAt(config.Env.BusinessLocation).Format(time.RFC3339),
+		"timezone":          config.Env.BusinessTimezone,
+		"appointmentType":   appointment.AppointmentType.Name,
+		"appointmentTypeID": appointment.AppointmentTypeID,
+		"bankTransfer":      appointment.BankTransfer,
+		"status":            appointment.Status,
+		"rejectionReason":   appointment.RejectionReason,
+		"createdAt":         appointment.CreatedAt,
+	}
+
+	// Los datos de la reunión solo se muestran a quien verifica ser el cliente
+	verified := isAppointmentOwner(c, appointment)
+	response["verified"] = verified
+
+	// Sin verificar, los datos personales van enmascarados: el teléfono nunca se muestra
+	// porque sus últimos 4 dígitos son los que verifican al cliente
+	if verified {
+		response["lastName"] = appointment.LastName
+		response["email"] = appointment.Email
+		// Formato del teléfono para mostrar: +1 (###) ###-####
+		phone := appointment.PhoneNumber
+		if len(phone) == 10 {
+			phone = fmt.Sprintf("+1(%s) %s-%s", phone[0:3], phone[3:6], phone[6:10])
+		}
+		response["phoneNumber"] = phone
 	} else {
-		phoneFormatted = phone
+		response["lastName"] = maskName(appointment.LastName)
+		response["email"] = maskEmail(appointment.Email)
+		response["phoneNumber"] = "***-***-****"
+	}
+	if verified && appointment.Status == models.StatusApproved {
+		platformName := ""
+		if appointment.MeetingPlatform != nil {
+			platformName = appointment.MeetingPlatform.Name
+		}
+		response["meetingLink"] = appointment.MeetingLink
+		response["meetingPlatform"] = platformName
+		response["adminNote"] = appointment.AdminNote
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"id":              appointment.ID,
-		"shortID":         appointment.ShortID,
-		"firstName":       appointment.FirstName,
-		"lastName":        appointment.LastName,
-		"email":           appointment.Email,
-		"phoneNumber":     phoneFormatted,
-		"appointmentDate": appointment.AppointmentDate,
-		"appointmentHour": appointment.AppointmentHour,
-		"appointmentType": appointment.AppointmentType.Name,
-		"bankTransfer":    appointment.BankTransfer,
-		"status":          appointment.Status,
-		"rejectionReason": appointment.RejectionReason,
-		"createdAt":       appointment.CreatedAt,
-	})
+	c.JSON(http.StatusOK, response)
+}
+
+// GetAppointmentCalendar descarga la cita como archivo .ics para agregarla al calendario.
+// Requiere la misma verificación que los datos de la reunión, que se incluyen si está aprobada.
+func GetAppointmentCalendar(c *gin.Context) {
+	appointment, ok := findAppointmentByCode(c, initializers.DB.Preload("AppointmentType").Preload("MeetingPlatform"))
+	if !ok {
+		return
+	}
+
+	if !isAppointmentOwner(c, appointment) {
+		c.JSON(http.StatusForbidden, gin.H{"error": translate(c, "Verification required")})
+		return
+	}
+	if !appointment.Status.IsActive() {
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, "Only pending or approved appointments can be added to a calendar")})
+		return
+	}
+
+	// El idioma pedido por el navegador manda; si no hay, el de la reserva
+	locale := i18n.FromRequest(c.Request, appointment.Locale.OrDefault())
+	event := services.AppointmentCalendarEvent(&appointment, appointment.Status == models.StatusApproved, locale)
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="cita-%s.ics"`, appointment.ShortID))
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	if err := (ical.Calendar{Events: []ical.Event{event}}).Write(c.Writer); err != nil {
+		fmt.Println("Error writing calendar file:", err)
+	}
+}
+
+// isAppointmentOwner verifica que quien consulta es el cliente: con el token de gestión
+// (?token) o con los últimos 4 dígitos del teléfono (?phoneLast4)
+func isAppointmentOwner(c *gin.Context, appointment models.Appointment) bool {
+	if token := c.Query("token"); token != "" && appointment.ManageToken != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(appointment.ManageToken)) == 1 {
+		return true
+	}
+	phoneLast4 := c.Query("phoneLast4")
+	return len(phoneLast4) == 4 && strings.HasSuffix(appointment.PhoneNumber, phoneLast4)
+}
+
+// maskName deja solo la inicial: "Pérez" -> "P."
+func maskName(name string) string {
+	for _, r := range strings.TrimSpace(name) {
+		return string(r) + "."
+	}
+	return ""
+}
+
+// maskEmail deja la primera letra del usuario y el dominio: "ana@mail.com" -> "a***@mail.com"
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return "***"
+	}
+	first := []rune(email[:at])[0]
+	return string(first) + "***" + email[at:]
 }
 
 // GetReceipt devuelve el archivo de comprobante
 func GetReceipt(c *gin.Context) {
-	shortID := c.Param("shortID")
-
-	var appointment models.Appointment
-	if err := initializers.DB.Where("short_id = ?", shortID).First(&appointment).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+	appointment, ok := findAppointmentByCode(c, initializers.DB)
+	if !ok {
 		return
 	}
 
 	// Verificar que el archivo existe
 	if _, err := os.Stat(appointment.ReceiptPath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Receipt file not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Receipt file not found")})
 		return
 	}
 
 	c.File(appointment.ReceiptPath)
 }
 
+// findAppointmentByCode busca la cita del código de la ruta (:shortID). Los códigos del
+// formato anterior (8 hex) se pueden adivinar, así que además exigen verificar al cliente
+// (ver isAppointmentOwner). Si no la encuentra ya respondió 404, con el mismo mensaje
+// para código o verificación incorrectos.
+func findAppointmentByCode(c *gin.Context, query *gorm.DB) (models.Appointment, bool) {
+	code := models.NormalizeLookupCode(c.Param("shortID"))
+
+	var appointment models.Appointment
+	err := query.Where("short_id = ?", code).First(&appointment).Error
+	if err == nil && models.IsLegacyShortID(code) && !isAppointmentOwner(c, appointment) {
+		err = gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
+		return appointment, false
+	}
+	return appointment, true
+}
+
 // GetReceiptByID devuelve el archivo de comprobante por ID (para admin)
 func GetReceiptByID(c *gin.Context) {
 	id := c.Param("id")
 
 	var appointment models.Appointment
 	if err := initializers.DB.Where("id = ?", id).First(&appointment).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
 		return
 	}
 
 	// Verificar que el archivo existe
 	if _, err := os.Stat(appointment.ReceiptPath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Receipt file not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Receipt file not found")})
 		return
 	}
 
 	c.File(appointment.ReceiptPath)
 }
 
-// GetAvailableHours obtiene las horas disponibles para una fecha
+// GetAvailableHours obtiene las franjas disponibles para una fecha.
+// Si se indica appointmentTypeID se usa la duración de ese tipo de cita.
+// Las horas se calculan en la zona horaria del negocio; si se indica tz (IANA)
+// cada franja incluye además su fecha y hora en la zona del cliente.
 func GetAvailableHours(c *gin.Context) {
 	dateStr := c.Query("date")
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid date format")})
 		return
 	}
 
-	// Todas las 24 horas por defecto (0-23)
-	allHours := make(map[int]bool)
-	for hour := 0; hour < 24; hour++ {
-		allHours[hour] = true
+	var clientLoc *time.Location
+	if tz := c.Query("tz"); tz != "" {
+		clientLoc, err = time.LoadLocation(tz)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid timezone")})
+			return
+		}
 	}
 
-	dayOfWeek := int(date.Weekday())
-
-	// 1. Aplicar reglas de día de semana
-	var weekdayRules []models.AvailabilityRule
-	initializers.DB.Where("day_of_week = ?", dayOfWeek).Find(&weekdayRules)
-
-	for _, rule := range weekdayRules {
-		if rule.AllDay {
-			// Bloquear todo el día
-			for hour := 0; hour < 24; hour++ {
-				allHours[hour] = false
-			}
-		} else {
-			// Bloquear horas específicas
-			for _, hour := range rule.UnavailableHours {
-				if hour >= 0 && hour < 24 {
-					allHours[hour] = false
-				}
-			}
+	duration := config.Env.SlotIntervalMinutes
+	var appointmentType models.AppointmentType
+	if typeIDStr := c.Query("appointmentTypeID"); typeIDStr != "" {
+		if err := initializers.DB.First(&appointmentType, "id = ?", typeIDStr).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
+			return
 		}
+		duration = appointmentType.DurationMinutes
 	}
 
-	// 2. Aplicar reglas de fecha específica (tienen prioridad)
-	var specificDateRules []models.AvailabilityRule
-	specificDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
-	initializers.DB.Where("specific_date = ?", specificDate).Find(&specificDateRules)
+	// Reglas, citas y reservas temporales del día; se descartan las franjas
+	// pasadas, fuera de la ventana de reserva, bloqueadas o sin lugar
+	schedule := availability.Load(initializers.DB, date, "")
+	slots, _ := schedule.AvailableSlots(appointmentType, duration)
 
-	for _, rule := range specificDateRules {
-		if rule.AllDay {
-			// Bloquear todo el día
-			for hour := 0; hour < 24; hour++ {
-				allHours[hour] = false
-			}
-		} else {
-			// Bloquear horas específicas
-			for _, hour := range rule.UnavailableHours {
-				if hour >= 0 && hour < 24 {
-					allHours[hour] = false
-				}
-			}
+	availableSlots := []gin.H{}
+	availableHours := []int{}
+	seatsByHour := make(map[int]int)
+	for _, open := range slots {
+		startsAt := models.DateTimeIn(date, open.Start, config.Env.BusinessLocation)
+		slot := gin.H{
+			"startMinute":    open.Start,
+			"endMinute":      open.End,
+			"startTime":      models.FormatMinutes(open.Start),
+			"endTime":        models.FormatMinutes(open.End),
+			"startsAt":       startsAt.Format(time.RFC3339),
+			"remainingSeats": open.Seats,
+		}
+		if clientLoc != nil {
+			clientStart := startsAt.In(clientLoc)
+			clientEnd := startsAt.Add(time.Duration(duration) * time.Minute).In(clientLoc)
+			slot["clientDate"] = clientStart.Format("2006-01-02")
+			slot["clientStartTime"] = clientStart.Format("15:04")
+			slot["clientEndTime"] = clientEnd.Format("15:04")
+		}
+		availableSlots = append(availableSlots, slot)
+		// Compatibilidad: franjas que empiezan en hora en punto
+		if open.Start%60 == 0 {
+			availableHours = append(availableHours, open.Start/60)
+			seatsByHour[open.Start/60] = open.Seats
 		}
 	}
 
-	// 3. Eliminar horas que ya tienen citas
-	for hour := 0; hour < 24; hour++ {
-		if !allHours[hour] {
-			continue
-		}
+	c.JSON(http.StatusOK, gin.H{
+		"date":            dateStr,
+		"timezone":        config.Env.BusinessTimezone,
+		"clientTimezone":  c.Query("tz"),
+		"durationMinutes": duration,
+		"capacity":        schedule.Capacity(),
+		"availableSlots":  availableSlots,
+		"availableHours":  availableHours,
+		"remainingSeats":  seatsByHour,
+	})
+}
 
-		var existingAppointment models.Appointment
-		result := initializers.DB.Where("appointment_date = ? AND appointment_hour = ? AND status != ?",
-			date, hour, models.StatusRejected).First(&existingAppointment)
+// maxAvailabilityRangeDays limita el rango que se puede consultar de una vez
+const maxAvailabilityRangeDays = 62
 
-		if result.Error == nil {
-			// Hay una cita, eliminar esta hora
-			allHours[hour] = false
-		}
+// GetAvailabilityRange obtiene las franjas disponibles de cada día entre from y to
+// (inclusive) para el selector de fechas. Cada día indica si está bloqueado (ninguna
+// franja permitida por reglas u horario) o completo (todas las franjas permitidas llenas).
+func GetAvailabilityRange(c *gin.Context) {
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid from date")})
+		return
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid to date")})
+		return
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "to must not be before from")})
+		return
+	}
+	if to.Sub(from) >= maxAvailabilityRangeDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Range cannot exceed %d days", maxAvailabilityRangeDays)})
+		return
 	}
 
-	// 4. Eliminar horas pasadas si es el día de hoy
-	now := time.Now()
-	isToday := date.Year() == now.Year() && date.Month() == now.Month() && date.Day() == now.Day()
-
-	if isToday {
-		currentHour := now.Hour()
-		for hour := 0; hour <= currentHour; hour++ {
-			allHours[hour] = false
+	duration := config.Env.SlotIntervalMinutes
+	var appointmentType models.AppointmentType
+	if typeIDStr := c.Query("appointmentTypeID"); typeIDStr != "" {
+		if err := initializers.DB.First(&appointmentType, "id = ?", typeIDStr).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
+			return
 		}
+		duration = appointmentType.DurationMinutes
 	}
 
-	// Convertir map a slice ordenado
-	availableHours := []int{}
-	for hour := 0; hour < 24; hour++ {
-		if allHours[hour] {
-			availableHours = append(availableHours, hour)
+	schedules := availability.LoadRange(initializers.DB, from, to, "")
+
+	days := []gin.H{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		slots, allowed := schedules[day.Format("2006-01-02")].AvailableSlots(appointmentType, duration)
+
+		availableSlots := []gin.H{}
+		availableHours := []int{}
+		for _, open := range slots {
+			availableSlots = append(availableSlots, gin.H{
+				"startTime":      models.FormatMinutes(open.Start),
+				"endTime":        models.FormatMinutes(open.End),
+				"remainingSeats": open.Seats,
+			})
+			if open.Start%60 == 0 {
+				availableHours = append(availableHours, open.Start/60)
+			}
 		}
+
+		days = append(days, gin.H{
+			"date":           day.Format("2006-01-02"),
+			"blocked":        allowed == 0,
+			"fullyBooked":    allowed > 0 && len(slots) == 0,
+			"availableSlots": availableSlots,
+			"availableHours": availableHours,
+		})
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"date":           dateStr,
-		"availableHours": availableHours,
+		"from":            from.Format("2006-01-02"),
+		"to":              to.Format("2006-01-02"),
+		"timezone":        config.Env.BusinessTimezone,
+		"durationMinutes": duration,
+		"days":            days,
 	})
 }
 
+// parseStartMinute obtiene el minuto de inicio de una cita desde "HH:MM" o,
+// por compatibilidad con clientes antiguos, desde una hora entera (0-23)
+func parseStartMinute(timeStr string, hour *int) (int, error) {
+	if timeStr != "" {
+		return models.ParseTimeOfDay(timeStr)
+	}
+	if hour == nil {
+		return 0, fmt.Errorf("missing appointment time")
+	}
+	if *hour < 0 || *hour > 23 {
+		return 0, fmt.Errorf("hour out of range: %d", *hour)
+	}
+	return *hour * 60, nil
+}
+
+// publicAppointmentType expone un tipo de cita con su ventana de reserva ya resuelta,
+// para que el frontend pueda deshabilitar las fechas fuera de rango
+type publicAppointmentType struct {
+	models.AppointmentType
+	MinLeadMinutes int
+	MaxHorizonDays int
+	EarliestDate   string
+	LatestDate     *string
+}
+
 // GetAppointmentTypes obtiene todos los tipos de cita visibles
 func GetAppointmentTypes(c *gin.Context) {
 	var appointmentTypes []models.AppointmentType
 	initializers.DB.Where("visible = true").Find(&appointmentTypes)
 
+	result := make([]publicAppointmentType, 0, len(appointmentTypes))
+	for _, appointmentType := range appointmentTypes {
+		lead, horizon := availability.BookingWindow(appointmentType)
+		first, last := availability.BookingDateRange(appointmentType)
+		entry := publicAppointmentType{
+			AppointmentType: appointmentType,
+			MinLeadMinutes:  lead,
+			MaxHorizonDays:  horizon,
+			EarliestDate:    first.Format("2006-01-02"),
+		}
+		if last != nil {
+			latest := last.Format("2006-01-02")
+			entry.LatestDate = &latest
+		}
+		result = append(result, entry)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"appointmentTypes": appointmentTypes,
+		"appointmentTypes": result,
 	})
 }
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services"
+	"pixelbrew-llc/ktrav3l_backend/services/mailer"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// useMemoryMailer hace que los handlers envíen los emails a un mailer.Memory durante el test
+func useMemoryMailer(t *testing.T) *mailer.Memory {
+	t.Helper()
+	memory := mailer.NewMemory()
+	previous := emailService
+	emailService = services.NewEmailServiceWithMailer(memory)
+	t.Cleanup(func() { emailService = previous })
+	return memory
+}
+
+// waitForEmails espera a que se hayan enviado n emails, incluidos los que se envían en segundo plano
+func waitForEmails(t *testing.T, memory *mailer.Memory, n int) []mailer.Message {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(memory.Messages()) < n && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	messages := memory.Messages()
+	if len(messages) < n {
+		t.Fatalf("sent %d emails, want %d", len(messages), n)
+	}
+	return messages
+}
+
+func TestCreateAppointmentSendsConfirmation(t *testing.T) {
+	setupTestDB(t)
+	memory := useMemoryMailer(t)
+	appointmentType := testAppointmentType(t)
+	date := testBookingDate(t, 2)
+
+	router := gin.New()
+	router.POST("/appointments", CreateAppointment)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, bookingRequest(t, date, "10:00", appointmentType.ID, 1))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body.String())
+	}
+
+	// La confirmación al cliente y el aviso al admin
+	waitForEmails(t, memory, 2)
+
+	var appointment models.Appointment
+	initializers.DB.Where("appointment_date = ?", date).First(&appointment)
+
+	sent := memory.SentTo("cliente1@ktravel.test")
+	if len(sent) != 1 {
+		t.Fatalf("sent %d emails to the client, want 1", len(sent))
+	}
+	msg := sent[0]
+	locale := appointment.Locale.OrDefault()
+	if want := i18n.T(locale, "email.confirmation.subject", appointment.ShortID); msg.Subject != want {
+		t.Errorf("Subject = %q, want %q", msg.Subject, want)
+	}
+	for _, want := range []string{appointment.ShortID, appointment.ManageToken, appointmentType.Name} {
+		if !strings.Contains(msg.HTML, want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAppointmentHistory devuelve los cambios registrados de una cita, del más antiguo al más reciente
+func GetAppointmentHistory(c *gin.Context) {
+	id := c.Param("id")
+
+	var appointment models.Appointment
+	if err := initializers.DB.Unscoped().First(&appointment, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
+		return
+	}
+
+	var events []models.AppointmentEvent
+	initializers.DB.Where("appointment_id = ?", appointment.ID).Order("created_at ASC, id ASC").Find(&events)
+
+	c.JSON(http.StatusOK, gin.H{
+		"appointmentId": appointment.ID,
+		"shortId":       appointment.ShortID,
+		"events":        events,
+	})
+}
+
+// recordAppointmentEvent guarda en el historial el cambio de before a after. El autor es el
+// usuario que dejó RequireAuth en el contexto; sin usuario es el cliente, y sin contexto
+// (procesos automáticos) el sistema. Un error al registrar no revierte el cambio.
+func recordAppointmentEvent(c *gin.Context, action models.AppointmentEventAction, before, after models.Appointment, note string) {
+	event := models.AppointmentEvent{
+		AppointmentID: after.ID,
+		Action:        action,
+		FromStatus:    before.Status,
+		ToStatus:      after.Status,
+		Actor:         models.ActorSystem,
+		Changes:       models.DiffAppointments(before, after),
+		Note:          note,
+	}
+
+	if c != nil {
+		event.Actor = models.ActorClient
+		if value, exists := c.Get("user"); exists {
+			if user, ok := value.(models.User); ok {
+				event.Actor = models.ActorAdmin
+				event.UserID = &user.ID
+				event.UserEmail = user.Email
+			}
+		}
+	}
+
+	if err := initializers.DB.Create(&event).Error; err != nil {
+		fmt.Println("Error recording appointment event:", err)
+	}
+}
@@ -8,12 +8,52 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-// GetAvailabilityRules obtiene todas las reglas de disponibilidad
+// scopeAdvisor limita una consulta de reglas a las de un asesor o, si advisorID es nil, a las generales
+func scopeAdvisor(query *gorm.DB, advisorID *uuid.UUID) *gorm.DB {
+	if advisorID == nil {
+		return query.Where("advisor_id IS NULL")
+	}
+	return query.Where("advisor_id = ?", *advisorID)
+}
+
+// advisorFromQuery lee el parámetro opcional advisorId
+func advisorFromQuery(c *gin.Context) (*uuid.UUID, error) {
+	advisorIDStr := c.Query("advisorId")
+	if advisorIDStr == "" {
+		return nil, nil
+	}
+	advisorID, err := uuid.Parse(advisorIDStr)
+	if err != nil {
+		return nil, err
+	}
+	return &advisorID, nil
+}
+
+// parseRuleMode valida el modo de una regla; vacío equivale a block
+func parseRuleMode(mode string) (models.RuleMode, bool) {
+	switch models.RuleMode(mode) {
+	case "", models.RuleModeBlock:
+		return models.RuleModeBlock, true
+	case models.RuleModeOpen:
+		return models.RuleModeOpen, true
+	}
+	return "", false
+}
+
+// GetAvailabilityRules obtiene todas las reglas de disponibilidad.
+// Con advisorId devuelve solo las reglas de ese asesor.
 func GetAvailabilityRules(c *gin.Context) {
+	query := initializers.DB.Order("day_of_week ASC, specific_date ASC")
+	if advisorIDStr := c.Query("advisorId"); advisorIDStr != "" {
+		query = query.Where("advisor_id = ?", advisorIDStr)
+	}
+
 	var rules []models.AvailabilityRule
-	initializers.DB.Order("day_of_week ASC, specific_date ASC").Find(&rules)
+	query.Find(&rules)
 
 	c.JSON(http.StatusOK, gin.H{
 		"rules": rules,
@@ -23,9 +63,13 @@ func GetAvailabilityRules(c *gin.Context) {
 // CreateWeekdayRule crea una regla para un día de la semana
 func CreateWeekdayRule(c *gin.Context) {
 	var body struct {
-		DayOfWeek        int   `json:"dayOfWeek" binding:"min=0,max=6"`
-		UnavailableHours []int `json:"unavailableHours"`
-		AllDay           bool  `json:"allDay"`
+		DayOfWeek        int        `json:"dayOfWeek" binding:"min=0,max=6"`
+		UnavailableHours []int      `json:"unavailableHours"`
+		AllDay           bool       `json:"allDay"`
+		Capacity         *int       `json:"capacity" binding:"omitempty,min=1"`
+		AdvisorID        *uuid.UUID `json:"advisorId"`                                        // null = regla general
+		OnConflict       string     `json:"onConflict" binding:"omitempty,oneof=reject move"` // Resolver citas que quedan bloqueadas
+		ConflictReason   string     `json:"conflictReason"`
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
@@ -33,20 +77,21 @@ func CreateWeekdayRule(c *gin.Context) {
 		return
 	}
 
-	// Verificar si ya existe una regla para este día
+	// Verificar si ya existe una regla para este día (y asesor)
 	var existingRule models.AvailabilityRule
-	result := initializers.DB.Where("day_of_week = ?", body.DayOfWeek).First(&existingRule)
+	result := scopeAdvisor(initializers.DB.Where("day_of_week = ?", body.DayOfWeek), body.AdvisorID).First(&existingRule)
 
 	if result.Error == nil {
 		// Ya existe, actualizar
 		existingRule.UnavailableHours = body.UnavailableHours
 		existingRule.AllDay = body.AllDay
+		existingRule.Capacity = body.Capacity
 		initializers.DB.Save(&existingRule)
 
-		c.JSON(http.StatusOK, gin.H{
+		c.JSON(http.StatusOK, conflictResponse(c, gin.H{
 			"message": "Rule updated successfully",
 			"rule":    existingRule,
-		})
+		}, weekdayRuleConflicts(existingRule), body.OnConflict, body.ConflictReason))
 		return
 	}
 
@@ -55,25 +100,34 @@ func CreateWeekdayRule(c *gin.Context) {
 		DayOfWeek:        &body.DayOfWeek,
 		UnavailableHours: body.UnavailableHours,
 		AllDay:           body.AllDay,
+		Mode:             models.RuleModeBlock,
+		Capacity:         body.Capacity,
+		AdvisorID:        body.AdvisorID,
 	}
 
 	if err := initializers.DB.Create(&rule).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create rule"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to create rule")})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	c.JSON(http.StatusCreated, conflictResponse(c, gin.H{
 		"message": "Rule created successfully",
 		"rule":    rule,
-	})
+	}, weekdayRuleConflicts(rule), body.OnConflict, body.ConflictReason))
 }
 
 // CreateSpecificDateRule crea una regla para una fecha específica
 func CreateSpecificDateRule(c *gin.Context) {
 	var body struct {
-		SpecificDate     string `json:"specificDate" binding:"required"`
-		UnavailableHours []int  `json:"unavailableHours"`
-		AllDay           bool   `json:"allDay"`
+		SpecificDate     string     `json:"specificDate" binding:"required"`
+		UnavailableHours []int      `json:"unavailableHours"`
+		OpenHours        []int      `json:"openHours"` // Solo modo open
+		AllDay           bool       `json:"allDay"`
+		Mode             string     `json:"mode"` // "block" (por defecto) u "open"
+		Capacity         *int       `json:"capacity" binding:"omitempty,min=1"`
+		AdvisorID        *uuid.UUID `json:"advisorId"`                                        // null = regla general
+		OnConflict       string     `json:"onConflict" binding:"omitempty,oneof=reject move"` // Resolver citas que quedan bloqueadas
+		ConflictReason   string     `json:"conflictReason"`
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
@@ -81,27 +135,36 @@ func CreateSpecificDateRule(c *gin.Context) {
 		return
 	}
 
+	mode, ok := parseRuleMode(body.Mode)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid rule mode")})
+		return
+	}
+
 	// Parse la fecha
 	date, err := time.Parse("2006-01-02", body.SpecificDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid date format")})
 		return
 	}
 
-	// Verificar si ya existe una regla para esta fecha
+	// Verificar si ya existe una regla para esta fecha (y asesor)
 	var existingRule models.AvailabilityRule
-	result := initializers.DB.Where("specific_date = ?", date).First(&existingRule)
+	result := scopeAdvisor(initializers.DB.Where("specific_date = ?", date), body.AdvisorID).First(&existingRule)
 
 	if result.Error == nil {
 		// Ya existe, actualizar
 		existingRule.UnavailableHours = body.UnavailableHours
+		existingRule.OpenHours = body.OpenHours
 		existingRule.AllDay = body.AllDay
+		existingRule.Mode = mode
+		existingRule.Capacity = body.Capacity
 		initializers.DB.Save(&existingRule)
 
-		c.JSON(http.StatusOK, gin.H{
+		c.JSON(http.StatusOK, conflictResponse(c, gin.H{
 			"message": "Rule updated successfully",
 			"rule":    existingRule,
-		})
+		}, ruleConflicts(date, date, scopeOf(existingRule)), body.OnConflict, body.ConflictReason))
 		return
 	}
 
@@ -109,18 +172,22 @@ func CreateSpecificDateRule(c *gin.Context) {
 	rule := models.AvailabilityRule{
 		SpecificDate:     &date,
 		UnavailableHours: body.UnavailableHours,
+		OpenHours:        body.OpenHours,
 		AllDay:           body.AllDay,
+		Mode:             mode,
+		Capacity:         body.Capacity,
+		AdvisorID:        body.AdvisorID,
 	}
 
 	if err := initializers.DB.Create(&rule).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create rule"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to create rule")})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	c.JSON(http.StatusCreated, conflictResponse(c, gin.H{
 		"message": "Rule created successfully",
 		"rule":    rule,
-	})
+	}, ruleConflicts(date, date, scopeOf(rule)), body.OnConflict, body.ConflictReason))
 }
 
 // UpdateAvailabilityRule actualiza una regla existente
@@ -129,13 +196,18 @@ func UpdateAvailabilityRule(c *gin.Context) {
 
 	var rule models.AvailabilityRule
 	if err := initializers.DB.First(&rule, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Rule not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Rule not found")})
 		return
 	}
 
 	var body struct {
-		UnavailableHours []int `json:"unavailableHours"`
-		AllDay           bool  `json:"allDay"`
+		UnavailableHours []int  `json:"unavailableHours"`
+		OpenHours        []int  `json:"openHours"`
+		AllDay           bool   `json:"allDay"`
+		Mode             string `json:"mode"`
+		Capacity         *int   `json:"capacity" binding:"omitempty,min=1"`
+		OnConflict       string `json:"onConflict" binding:"omitempty,oneof=reject move"`
+		ConflictReason   string `json:"conflictReason"`
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
@@ -143,18 +215,38 @@ func UpdateAvailabilityRule(c *gin.Context) {
 		return
 	}
 
+	mode, ok := parseRuleMode(body.Mode)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid rule mode")})
+		return
+	}
+	if mode == models.RuleModeOpen && rule.SpecificDate == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Open mode is only allowed for specific-date rules")})
+		return
+	}
+
 	rule.UnavailableHours = body.UnavailableHours
+	rule.OpenHours = body.OpenHours
 	rule.AllDay = body.AllDay
+	rule.Mode = mode
+	rule.Capacity = body.Capacity
 
 	if err := initializers.DB.Save(&rule).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update rule"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to update rule")})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	var conflicts []models.Appointment
+	if rule.SpecificDate != nil {
+		conflicts = ruleConflicts(*rule.SpecificDate, *rule.SpecificDate, scopeOf(rule))
+	} else {
+		conflicts = weekdayRuleConflicts(rule)
+	}
+
+	c.JSON(http.StatusOK, conflictResponse(c, gin.H{
 		"message": "Rule updated successfully",
 		"rule":    rule,
-	})
+	}, conflicts, body.OnConflict, body.ConflictReason))
 }
 
 // DeleteAvailabilityRule elimina una regla
@@ -163,12 +255,12 @@ func DeleteAvailabilityRule(c *gin.Context) {
 
 	var rule models.AvailabilityRule
 	if err := initializers.DB.First(&rule, id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Rule not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Rule not found")})
 		return
 	}
 
 	if err := initializers.DB.Delete(&rule).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete rule"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to delete rule")})
 		return
 	}
 
@@ -182,12 +274,18 @@ func DeleteWeekdayRule(c *gin.Context) {
 	dayStr := c.Param("day")
 	day, err := strconv.Atoi(dayStr)
 	if err != nil || day < 0 || day > 6 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid day of week"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid day of week")})
 		return
 	}
 
-	if err := initializers.DB.Where("day_of_week = ?", day).Delete(&models.AvailabilityRule{}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete rule"})
+	advisorID, err := advisorFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid advisor ID")})
+		return
+	}
+
+	if err := scopeAdvisor(initializers.DB.Where("day_of_week = ?", day), advisorID).Delete(&models.AvailabilityRule{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to delete rule")})
 		return
 	}
 
@@ -201,12 +299,18 @@ func DeleteSpecificDateRule(c *gin.Context) {
 	dateStr := c.Param("date")
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid date format")})
+		return
+	}
+
+	advisorID, err := advisorFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid advisor ID")})
 		return
 	}
 
-	if err := initializers.DB.Where("specific_date = ?", date).Delete(&models.AvailabilityRule{}).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete rule"})
+	if err := scopeAdvisor(initializers.DB.Where("specific_date = ?", date), advisorID).Delete(&models.AvailabilityRule{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to delete rule")})
 		return
 	}
 
@@ -214,3 +318,248 @@ func DeleteSpecificDateRule(c *gin.Context) {
 		"message": "Specific date rule deleted successfully",
 	})
 }
+
+// maxRuleRangeDays limita cuántos días se pueden modificar en una sola operación
+const maxRuleRangeDays = 366
+
+// ApplyRuleRange bloquea o desbloquea todas las fechas de un rango (opcionalmente solo
+// ciertos días de semana) en una sola transacción. Solo cambia las horas pedidas (todo el
+// día si unavailableHours está vacío) en las reglas de fecha específica de cada día: el
+// cupo y las horas abiertas de las reglas existentes se conservan (ver applyRangeToDate).
+// Con preview no se guarda nada y solo se devuelven las fechas y las citas pendientes o
+// aprobadas afectadas.
+func ApplyRuleRange(c *gin.Context) {
+	var body struct {
+		StartDate        string     `json:"startDate" binding:"required"`
+		EndDate          string     `json:"endDate" binding:"required"`
+		Action           string     `json:"action" binding:"required,oneof=block unblock"`
+		UnavailableHours []int      `json:"unavailableHours"` // Vacío = todo el día
+		Weekdays         []int      `json:"weekdays"`         // Vacío = todos los días
+		AdvisorID        *uuid.UUID `json:"advisorId"`        // null = reglas generales
+		Preview          bool       `json:"preview"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", body.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid startDate")})
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", body.EndDate)
+	if err != nil || endDate.Before(startDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid endDate")})
+		return
+	}
+	if endDate.Sub(startDate) >= maxRuleRangeDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Range cannot exceed 366 days")})
+		return
+	}
+
+	for _, hour := range body.UnavailableHours {
+		if hour < 0 || hour > 23 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid hour")})
+			return
+		}
+	}
+
+	weekdays := make(map[int]bool)
+	for _, day := range body.Weekdays {
+		if day < 0 || day > 6 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid weekday")})
+			return
+		}
+		weekdays[day] = true
+	}
+
+	var dates []time.Time
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		if len(weekdays) == 0 || weekdays[int(day.Weekday())] {
+			dates = append(dates, day)
+		}
+	}
+
+	affected := []gin.H{}
+	if body.Action == "block" {
+		affected = affectedAppointments(dates, body.UnavailableHours, body.AdvisorID)
+	}
+
+	dateStrs := make([]string, len(dates))
+	for i, day := range dates {
+		dateStrs[i] = day.Format("2006-01-02")
+	}
+
+	if body.Preview {
+		c.JSON(http.StatusOK, gin.H{
+			"preview":              true,
+			"action":               body.Action,
+			"dates":                dateStrs,
+			"affectedAppointments": affected,
+		})
+		return
+	}
+
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		for _, day := range dates {
+			if err := applyRangeToDate(tx, day, body.Action == "block", body.UnavailableHours, body.AdvisorID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to apply rules")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":              "Rules applied successfully",
+		"action":               body.Action,
+		"dates":                dateStrs,
+		"affectedAppointments": affected,
+	})
+}
+
+// applyRangeToDate bloquea o desbloquea hours (todo el día si está vacío) en las reglas
+// de fecha específica de date para el asesor (o las generales).
+//
+// block agrega las horas a la regla en modo block del día (o la crea) y también a las
+// UnavailableHours de las reglas en modo open, que se aplican después de abrir, para que
+// una regla open no vuelva a abrirlas. unblock quita solo esas horas de todas las reglas
+// del día; una regla block de todo el día pasa a cerrar las demás horas. Las reglas block
+// que quedan sin horas ni cupo se eliminan; las open y el cupo se conservan siempre.
+func applyRangeToDate(tx *gorm.DB, date time.Time, block bool, hours []int, advisorID *uuid.UUID) error {
+	allDay := len(hours) == 0
+	if allDay {
+		hours = allHours()
+	}
+
+	var rules []models.AvailabilityRule
+	if err := scopeAdvisor(tx.Where("specific_date = ?", date), advisorID).Order("id ASC").Find(&rules).Error; err != nil {
+		return err
+	}
+
+	if block {
+		var target *models.AvailabilityRule
+		for i := range rules {
+			rule := &rules[i]
+			if rule.Mode == models.RuleModeOpen {
+				rule.UnavailableHours = mergeHours(rule.UnavailableHours, hours)
+				if err := tx.Save(rule).Error; err != nil {
+					return err
+				}
+			} else if target == nil {
+				target = rule
+			}
+		}
+		if target == nil {
+			target = &models.AvailabilityRule{SpecificDate: &date, Mode: models.RuleModeBlock, AdvisorID: advisorID}
+		}
+		if allDay {
+			target.AllDay = true
+		} else if !target.AllDay {
+			target.UnavailableHours = mergeHours(target.UnavailableHours, hours)
+		}
+		return tx.Save(target).Error
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Mode != models.RuleModeOpen && rule.AllDay {
+			rule.AllDay = false
+			rule.UnavailableHours = allHours()
+		}
+		rule.UnavailableHours = removeHours(rule.UnavailableHours, hours)
+
+		if rule.Mode != models.RuleModeOpen && len(rule.UnavailableHours) == 0 && rule.Capacity == nil {
+			if err := tx.Delete(rule).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		if err := tx.Save(rule).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allHours devuelve las horas 0-23
+func allHours() []int {
+	hours := make([]int, 24)
+	for i := range hours {
+		hours[i] = i
+	}
+	return hours
+}
+
+// mergeHours devuelve la unión ordenada de las horas de a y b
+func mergeHours(a, b []int) models.IntArray {
+	var set [24]bool
+	for _, hour := range append(append([]int{}, a...), b...) {
+		if hour >= 0 && hour < 24 {
+			set[hour] = true
+		}
+	}
+	merged := models.IntArray{}
+	for hour, ok := range set {
+		if ok {
+			merged = append(merged, hour)
+		}
+	}
+	return merged
+}
+
+// removeHours devuelve las horas de a que no están en b
+func removeHours(a, b []int) models.IntArray {
+	var removed [24]bool
+	for _, hour := range b {
+		if hour >= 0 && hour < 24 {
+			removed[hour] = true
+		}
+	}
+	kept := models.IntArray{}
+	for _, hour := range a {
+		if hour < 0 || hour >= 24 || !removed[hour] {
+			kept = append(kept, hour)
+		}
+	}
+	return kept
+}
+
+// affectedAppointments devuelve las citas pendientes o aprobadas de esas fechas que
+// quedarían dentro de las horas bloqueadas (todo el día si hours está vacío)
+func affectedAppointments(dates []time.Time, hours []int, advisorID *uuid.UUID) []gin.H {
+	affected := []gin.H{}
+	if len(dates) == 0 {
+		return affected
+	}
+
+	query := initializers.DB.Preload("AppointmentType").
+		Where("appointment_date IN ? AND status IN ?", dates, models.ActiveStatuses).
+		Order("appointment_date ASC, start_minute ASC")
+	if advisorID != nil {
+		query = query.Where("advisor_id = ?", *advisorID)
+	}
+
+	var appointments []models.Appointment
+	query.Find(&appointments)
+
+	for _, appointment := range appointments {
+		blocked := len(hours) == 0
+		for _, hour := range hours {
+			if models.HourOverlaps(hour, appointment.StartMinute, appointment.EndMinute) {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			continue
+		}
+		affected = append(affected, appointmentSummary(appointment))
+	}
+	return affected
+}
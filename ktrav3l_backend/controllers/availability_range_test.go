@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"reflect"
+	"testing"
+)
+
+func TestApplyRangeToDateKeepsOtherRules(t *testing.T) {
+	setupTestDB(t)
+	date := testBookingDate(t, 2)
+	clearRules := func() {
+		initializers.DB.Unscoped().Where("specific_date = ?", date).Delete(&models.AvailabilityRule{})
+	}
+	clearRules()
+	t.Cleanup(clearRules)
+
+	capacity := 3
+	rules := []models.AvailabilityRule{
+		{SpecificDate: &date, Mode: models.RuleModeBlock, UnavailableHours: models.IntArray{8}, Capacity: &capacity},
+		{SpecificDate: &date, Mode: models.RuleModeOpen, OpenHours: models.IntArray{18, 19}},
+	}
+	if err := initializers.DB.Create(&rules).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	load := func() (block, open models.AvailabilityRule) {
+		var stored []models.AvailabilityRule
+		initializers.DB.Where("specific_date = ? AND advisor_id IS NULL", date).Order("id ASC").Find(&stored)
+		for _, rule := range stored {
+			if rule.Mode == models.RuleModeOpen {
+				open = rule
+			} else {
+				block = rule
+			}
+		}
+		return block, open
+	}
+
+	if err := applyRangeToDate(initializers.DB, date, true, []int{10, 11}, nil); err != nil {
+		t.Fatal(err)
+	}
+	block, open := load()
+	if !reflect.DeepEqual([]int(block.UnavailableHours), []int{8, 10, 11}) || block.Capacity == nil || *block.Capacity != 3 {
+		t.Fatalf("block rule after block = %+v", block)
+	}
+	if !reflect.DeepEqual([]int(open.OpenHours), []int{18, 19}) || !reflect.DeepEqual([]int(open.UnavailableHours), []int{10, 11}) {
+		t.Fatalf("open rule after block = %+v", open)
+	}
+
+	// unblock solo quita la hora pedida
+	if err := applyRangeToDate(initializers.DB, date, false, []int{10}, nil); err != nil {
+		t.Fatal(err)
+	}
+	block, open = load()
+	if !reflect.DeepEqual([]int(block.UnavailableHours), []int{8, 11}) || block.Capacity == nil {
+		t.Fatalf("block rule after unblock = %+v", block)
+	}
+	if !reflect.DeepEqual([]int(open.UnavailableHours), []int{11}) {
+		t.Fatalf("open rule after unblock = %+v", open)
+	}
+
+	// Desbloquear todo el día conserva el cupo y la regla open
+	if err := applyRangeToDate(initializers.DB, date, false, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	block, open = load()
+	if len(block.UnavailableHours) != 0 || block.AllDay || block.Capacity == nil || open.ID == 0 {
+		t.Fatalf("after unblocking the whole day: block=%+v open=%+v", block, open)
+	}
+}
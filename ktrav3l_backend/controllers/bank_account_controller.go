@@ -13,7 +13,7 @@ import (
 func GetBankAccounts(c *gin.Context) {
 	var accounts []models.BankAccount
 	if err := initializers.DB.Where("is_active = ?", true).Find(&accounts).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al obtener cuentas bancarias"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error al obtener cuentas bancarias")})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"accounts": accounts})
@@ -38,7 +38,7 @@ func CreateBankAccount(c *gin.Context) {
 	}
 
 	if err := initializers.DB.Create(&account).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al crear cuenta bancaria"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error al crear cuenta bancaria")})
 		return
 	}
 
@@ -50,7 +50,7 @@ func UpdateBankAccount(c *gin.Context) {
 	id := c.Param("id")
 	accountID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "ID inválido")})
 		return
 	}
 
@@ -67,7 +67,7 @@ func UpdateBankAccount(c *gin.Context) {
 
 	var account models.BankAccount
 	if err := initializers.DB.Where("id = ?", accountID).First(&account).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Cuenta no encontrada"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Cuenta no encontrada")})
 		return
 	}
 
@@ -83,7 +83,7 @@ func UpdateBankAccount(c *gin.Context) {
 	}
 
 	if err := initializers.DB.Save(&account).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar cuenta"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error al actualizar cuenta")})
 		return
 	}
 
@@ -95,19 +95,19 @@ func DeleteBankAccount(c *gin.Context) {
 	id := c.Param("id")
 	accountID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "ID inválido")})
 		return
 	}
 
 	var account models.BankAccount
 	if err := initializers.DB.Where("id = ?", accountID).First(&account).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Cuenta no encontrada"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Cuenta no encontrada")})
 		return
 	}
 
 	account.IsActive = false
 	if err := initializers.DB.Save(&account).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al desactivar cuenta"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error al desactivar cuenta")})
 		return
 	}
 
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services"
+	"pixelbrew-llc/ktrav3l_backend/services/ical"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	uuid "github.com/google/uuid"
+)
+
+// calendarFeedPastDays es cuántos días hacia atrás incluye el feed
+const calendarFeedPastDays = 30
+
+// GetCalendarFeedURL devuelve la URL del feed iCal del usuario, creando su token la primera vez
+func GetCalendarFeedURL(c *gin.Context) {
+	user := c.MustGet("user").(models.User)
+
+	if user.CalendarFeedToken == "" {
+		user.CalendarFeedToken = uuid.NewString()
+		if err := initializers.DB.Model(&user).Update("calendar_feed_token", user.CalendarFeedToken).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error creating calendar feed")})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, calendarFeedResponse(c, user.CalendarFeedToken))
+}
+
+// RotateCalendarFeed reemplaza el token del feed; la URL anterior deja de funcionar
+func RotateCalendarFeed(c *gin.Context) {
+	user := c.MustGet("user").(models.User)
+
+	token := uuid.NewString()
+	if err := initializers.DB.Model(&user).Update("calendar_feed_token", token).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error rotating calendar feed")})
+		return
+	}
+
+	c.JSON(http.StatusOK, calendarFeedResponse(c, token))
+}
+
+// GetCalendarFeed sirve las citas aprobadas como calendario iCal para suscribirse desde
+// cualquier app. Las apps de calendario no envían el JWT, así que el token de la URL
+// es la autenticación.
+func GetCalendarFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	var user models.User
+	if token == "" || initializers.DB.Where("calendar_feed_token = ?", token).First(&user).Error != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Calendar feed not found")})
+		return
+	}
+
+	now := config.Env.BusinessNow()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -calendarFeedPastDays)
+
+	var appointments []models.Appointment
+	initializers.DB.Preload("AppointmentType").Preload("MeetingPlatform").
+		Where("status = ? AND appointment_date >= ?", models.StatusApproved, from).
+		Order("appointment_date ASC, start_minute ASC").
+		Find(&appointments)
+
+	events := make([]ical.Event, 0, len(appointments))
+	for i := range appointments {
+		event := services.AppointmentCalendarEvent(&appointments[i], true, i18n.Default)
+		event.Summary = appointments[i].FirstName + " " + appointments[i].LastName + " - " + appointments[i].AppointmentType.Name
+		event.Sequence = appointments[i].CalendarSequence
+		events = append(events, event)
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", `inline; filename="ktravel.ics"`)
+	calendar := ical.Calendar{Method: ical.MethodPublish, Name: "KTravel - Citas aprobadas", Events: events}
+	if err := calendar.Write(c.Writer); err != nil {
+		fmt.Println("Error writing calendar feed:", err)
+	}
+}
+
+// calendarFeedResponse arma la URL pública del feed a partir del host de la petición
+func calendarFeedResponse(c *gin.Context, token string) gin.H {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	hostPath := c.Request.Host + "/calendar/feed/" + token + ".ics"
+
+	return gin.H{
+		"url":       scheme + "://" + hostPath,
+		"webcalUrl": "webcal://" + hostPath,
+	}
+}
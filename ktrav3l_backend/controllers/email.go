@@ -0,0 +1,10 @@
+package controllers
+
+import (
+	"pixelbrew-llc/ktrav3l_backend/services"
+)
+
+// emailService envía los emails de los handlers. Por defecto los guarda en la outbox;
+// los tests lo reemplazan por services.NewEmailServiceWithMailer(mailer.NewMemory())
+// para revisar lo enviado sin base de datos de emails ni worker.
+var emailService = services.NewEmailService()
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetOutboxEmails lista los emails de la outbox, por defecto los que fallaron
+// (status=failed|pending|sending|sent)
+func GetOutboxEmails(c *gin.Context) {
+	status := c.DefaultQuery("status", string(models.OutboxFailed))
+
+	var emails []models.OutboxEmail
+	initializers.DB.Omit("body", "attachments").
+		Where("status = ?", status).
+		Order("created_at DESC").
+		Limit(200).
+		Find(&emails)
+
+	c.JSON(http.StatusOK, gin.H{
+		"emails": emails,
+	})
+}
+
+// RetryOutboxEmail vuelve a poner en cola un email fallido con todos sus intentos
+func RetryOutboxEmail(c *gin.Context) {
+	id := c.Param("id")
+
+	var email models.OutboxEmail
+	if err := initializers.DB.Omit("body", "attachments").First(&email, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Email not found")})
+		return
+	}
+	if email.Status != models.OutboxFailed {
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, "Only failed emails can be retried")})
+		return
+	}
+
+	err := initializers.DB.Model(&email).Updates(map[string]interface{}{
+		"status":          models.OutboxPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error retrying email")})
+		return
+	}
+	services.WakeEmailWorker()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email queued for retry",
+		"email":   email,
+	})
+}
@@ -0,0 +1,22 @@
+package controllers
+
+import (
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// translate traduce un mensaje de error de la API al idioma que pidió el cliente con
+// ?lang= o Accept-Language; si no pidió ninguno soportado se responde en inglés
+func translate(c *gin.Context, message string, args ...interface{}) string {
+	return i18n.Error(i18n.FromRequest(c.Request, i18n.English), message, args...)
+}
+
+// bookingLocale es el idioma en que se le escribe al cliente de una reserva: el campo
+// locale si lo envió, si no el de su navegador y si no el del negocio
+func bookingLocale(c *gin.Context, value string) i18n.Locale {
+	if locale, ok := i18n.Parse(value); ok {
+		return locale
+	}
+	return i18n.FromRequest(c.Request, i18n.Default)
+}
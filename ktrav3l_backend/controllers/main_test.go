@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"os"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var syncTestDB sync.Once
+
+// setupTestDB conecta con la base de TEST_DATABASE_DSN y aplica las migraciones. Los
+// tests que la usan se saltan si no está definida. Debe ser una base desechable: los
+// tests crean y borran citas y tipos de cita.
+func setupTestDB(t *testing.T) {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+
+	gin.SetMode(gin.TestMode)
+	config.Env = &config.EnvConfig{
+		UploadsPath:              t.TempDir(),
+		FrontendURL:              "http://localhost:3000",
+		SMTPFrom:                 "citas@ktravel.test",
+		SMTPFromName:             "KTravel",
+		EmailMaxAttempts:         1,
+		SlotIntervalMinutes:      60,
+		SlotCapacity:             1,
+		SlotHoldMinutes:          10,
+		WaitlistClaimMinutes:     120,
+		SelfServiceCutoffMinutes: 1440,
+		LookupRateLimit:          30,
+		BusinessTimezone:         "UTC",
+		BusinessLocation:         time.UTC,
+	}
+
+	syncTestDB.Do(func() {
+		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if err != nil {
+			t.Fatalf("connect to test database: %v", err)
+		}
+		initializers.DB = db
+		initializers.SyncDB()
+	})
+	if initializers.DB == nil {
+		t.Fatal("test database not available")
+	}
+}
+
+// testBookingDate devuelve un miércoles lejano y libre de citas, distinto para cada offset
+func testBookingDate(t *testing.T, offset int) time.Time {
+	t.Helper()
+	day := time.Now().UTC().AddDate(0, 0, 200+offset*7)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	for day.Weekday() != time.Wednesday {
+		day = day.AddDate(0, 0, 1)
+	}
+	clear := func() {
+		initializers.DB.Unscoped().Where("appointment_date = ?", day).Delete(&models.Appointment{})
+	}
+	clear()
+	t.Cleanup(clear)
+	return day
+}
+
+// testAppointmentType crea un tipo de cita visible de una hora para el test
+func testAppointmentType(t *testing.T) models.AppointmentType {
+	t.Helper()
+	appointmentType := models.AppointmentType{Name: "Test " + t.Name(), DurationMinutes: 60, Visible: true}
+	if err := initializers.DB.Create(&appointmentType).Error; err != nil {
+		t.Fatalf("create appointment type: %v", err)
+	}
+	t.Cleanup(func() {
+		initializers.DB.Unscoped().Where("appointment_type_id = ?", appointmentType.ID).Delete(&models.Appointment{})
+		initializers.DB.Unscoped().Delete(&appointmentType)
+	})
+	return appointmentType
+}
@@ -36,7 +36,7 @@ func CreateMeetingPlatform(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Name is required")})
 		return
 	}
 
@@ -46,7 +46,7 @@ func CreateMeetingPlatform(c *gin.Context) {
 	}
 
 	if err := initializers.DB.Create(&platform).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating meeting platform"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error creating meeting platform")})
 		return
 	}
 
@@ -65,13 +65,13 @@ func UpdateMeetingPlatform(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&body); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data")})
 		return
 	}
 
 	var platform models.MeetingPlatform
 	if err := initializers.DB.First(&platform, "id = ?", id).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Meeting platform not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Meeting platform not found")})
 		return
 	}
 
@@ -83,7 +83,7 @@ func UpdateMeetingPlatform(c *gin.Context) {
 	}
 
 	if err := initializers.DB.Save(&platform).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating meeting platform"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating meeting platform")})
 		return
 	}
 
@@ -98,18 +98,18 @@ func DeleteMeetingPlatform(c *gin.Context) {
 
 	parsedID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid ID")})
 		return
 	}
 
 	var platform models.MeetingPlatform
 	if err := initializers.DB.First(&platform, "id = ?", parsedID).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Meeting platform not found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Meeting platform not found")})
 		return
 	}
 
 	if err := initializers.DB.Delete(&platform).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting meeting platform"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error deleting meeting platform")})
 		return
 	}
 
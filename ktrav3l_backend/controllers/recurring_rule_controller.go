@@ -0,0 +1,306 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services/ical"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetRecurringRules lista las reglas recurrentes (feriados, vacaciones, etc.)
+func GetRecurringRules(c *gin.Context) {
+	var rules []models.RecurringRule
+	initializers.DB.Order("kind ASC, month ASC, day ASC, start_date ASC").Find(&rules)
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+	})
+}
+
+// CreateRecurringRule crea una regla que se repite: cada año en una fecha, el n-ésimo
+// día de semana de cada mes o todos los días de un rango
+func CreateRecurringRule(c *gin.Context) {
+	var body struct {
+		Name             string     `json:"name" binding:"required"`
+		Kind             string     `json:"kind" binding:"required"`
+		Month            int        `json:"month" binding:"min=0,max=12"`
+		Day              int        `json:"day" binding:"min=0,max=31"`
+		Weekday          *int       `json:"weekday" binding:"omitempty,min=0,max=6"`
+		WeekOfMonth      int        `json:"weekOfMonth" binding:"min=-1,max=5"`
+		StartDate        string     `json:"startDate"` // YYYY-MM-DD
+		EndDate          string     `json:"endDate"`   // YYYY-MM-DD, inclusive
+		UnavailableHours []int      `json:"unavailableHours"`
+		OpenHours        []int      `json:"openHours"`
+		AllDay           *bool      `json:"allDay"` // Por defecto true
+		Mode             string     `json:"mode"`
+		AdvisorID        *uuid.UUID `json:"advisorId"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	mode, ok := parseRuleMode(body.Mode)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid rule mode")})
+		return
+	}
+
+	rule := models.RecurringRule{
+		Name:             body.Name,
+		Kind:             models.RecurrenceKind(body.Kind),
+		Month:            body.Month,
+		Day:              body.Day,
+		Weekday:          body.Weekday,
+		WeekOfMonth:      body.WeekOfMonth,
+		UnavailableHours: body.UnavailableHours,
+		OpenHours:        body.OpenHours,
+		AllDay:           body.AllDay == nil || *body.AllDay,
+		Mode:             mode,
+		AdvisorID:        body.AdvisorID,
+	}
+
+	switch rule.Kind {
+	case models.RecurrenceYearly:
+		if rule.Month == 0 || rule.Day == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "month and day are required")})
+			return
+		}
+	case models.RecurrenceMonthlyWeekday:
+		if rule.Weekday == nil || rule.WeekOfMonth == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "weekday and weekOfMonth are required")})
+			return
+		}
+	case models.RecurrenceDateRange:
+		startDate, err := time.Parse("2006-01-02", body.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid startDate")})
+			return
+		}
+		endDate, err := time.Parse("2006-01-02", body.EndDate)
+		if err != nil || endDate.Before(startDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid endDate")})
+			return
+		}
+		rule.StartDate = &startDate
+		rule.EndDate = &endDate
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid recurrence kind")})
+		return
+	}
+
+	if err := initializers.DB.Create(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to create rule")})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Rule created successfully",
+		"rule":    rule,
+	})
+}
+
+// DeleteRecurringRule elimina una regla recurrente
+func DeleteRecurringRule(c *gin.Context) {
+	id := c.Param("id")
+
+	var rule models.RecurringRule
+	if err := initializers.DB.First(&rule, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Rule not found")})
+		return
+	}
+
+	if err := initializers.DB.Delete(&rule).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to delete rule")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Rule deleted successfully",
+	})
+}
+
+// ImportHolidayCalendar importa un archivo iCalendar (campo "file") como reglas recurrentes
+// que cierran el día completo. Los eventos con RRULE anual sin fin se guardan como yearly; las
+// recurrencias con COUNT o UNTIL se expanden en un rango de fechas por repetición y el resto
+// de los eventos se guardan como rangos de fechas. Los eventos cuya recurrencia no se puede
+// representar se omiten y se informan en "skipped". Reimportar el mismo archivo actualiza
+// las reglas por UID y elimina las repeticiones que ya no están en el calendario.
+func ImportHolidayCalendar(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Calendar file is required")})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Error reading calendar file")})
+		return
+	}
+	defer file.Close()
+
+	events, err := ical.Parse(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid calendar file: %s", err.Error())})
+		return
+	}
+
+	// Una repetición modificada reemplaza a la original del evento recurrente
+	for _, event := range events {
+		if event.RecurrenceID.IsZero() {
+			continue
+		}
+		for i := range events {
+			if events[i].UID == event.UID && events[i].RecurrenceID.IsZero() {
+				events[i].ExDates = append(events[i].ExDates, event.RecurrenceID)
+			}
+		}
+	}
+
+	created, updated, removed := 0, 0, int64(0)
+	skipped := []gin.H{}
+	imported := map[string][]string{} // ExternalUID de las reglas importadas, por UID de evento
+	skippedUIDs := map[string]bool{}  // Sus reglas anteriores se conservan
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		for _, event := range events {
+			rules, err := holidayRules(event)
+			if err != nil {
+				skipped = append(skipped, gin.H{
+					"uid":     event.UID,
+					"summary": event.Summary,
+					"reason":  recurrenceError(c, err, event.Recurrence.Raw),
+				})
+				skippedUIDs[event.UID] = true
+				continue
+			}
+
+			for _, rule := range rules {
+				var existing models.RecurringRule
+				err := tx.Where("external_uid = ? AND external_uid <> ''", rule.ExternalUID).First(&existing).Error
+				switch {
+				case err == nil:
+					rule.ID = existing.ID
+					rule.CreatedAt = existing.CreatedAt
+					if err := tx.Save(&rule).Error; err != nil {
+						return err
+					}
+					updated++
+				case errors.Is(err, gorm.ErrRecordNotFound):
+					if err := tx.Create(&rule).Error; err != nil {
+						return err
+					}
+					created++
+				default:
+					return err
+				}
+				imported[event.UID] = append(imported[event.UID], rule.ExternalUID)
+			}
+		}
+
+		// Repeticiones de una importación anterior que el calendario ya no incluye
+		for uid, externalUIDs := range imported {
+			if uid == "" || skippedUIDs[uid] {
+				continue
+			}
+			result := tx.Where("(external_uid = ? OR starts_with(external_uid, ?)) AND external_uid NOT IN ?",
+				uid, uid+"/", externalUIDs).Delete(&models.RecurringRule{})
+			if result.Error != nil {
+				return result.Error
+			}
+			removed += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Failed to import calendar")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Calendar imported successfully",
+		"created": created,
+		"updated": updated,
+		"removed": removed,
+		"skipped": skipped,
+	})
+}
+
+// holidayRules convierte un evento importado en las reglas recurrentes que cierran sus días.
+// Una recurrencia anual sin fin es una regla yearly por cada día del evento; cualquier otro
+// evento es un rango de fechas por repetición. El ExternalUID de cada regla es el UID del
+// evento, seguido de "/MMDD" o "/YYYYMMDD" cuando el evento genera más de una regla o es
+// una repetición modificada (en ese caso, la fecha de la repetición original).
+func holidayRules(event ical.Event) ([]models.RecurringRule, error) {
+	// DTEND es exclusivo: un feriado de un día termina al día siguiente
+	days := int(event.End.Sub(event.Start).Hours()/24) - 1
+	if days < 0 {
+		days = 0
+	}
+	newRule := func(suffix string) models.RecurringRule {
+		rule := models.RecurringRule{Name: event.Summary, AllDay: true, Mode: models.RuleModeBlock, ExternalUID: event.UID}
+		if event.UID != "" && suffix != "" {
+			rule.ExternalUID += "/" + suffix
+		}
+		return rule
+	}
+
+	var rules []models.RecurringRule
+	if recurrence := event.Recurrence; recurrence != nil && recurrence.Freq == ical.FreqYearly && !recurrence.Bounded() {
+		if recurrence.Interval != 1 || !recurrence.Supported() || len(event.ExDates) > 0 {
+			return nil, ical.ErrUnsupportedRecurrence
+		}
+		for i := 0; i <= days; i++ {
+			day := event.Start.AddDate(0, 0, i)
+			suffix := ""
+			if days > 0 {
+				suffix = day.Format("0102")
+			}
+			rule := newRule(suffix)
+			rule.Kind = models.RecurrenceYearly
+			rule.Month = int(day.Month())
+			rule.Day = day.Day()
+			rules = append(rules, rule)
+		}
+		return rules, nil
+	}
+
+	occurrences, err := event.Occurrences()
+	if err != nil {
+		return nil, err
+	}
+	for _, startDate := range occurrences {
+		suffix := ""
+		switch {
+		case !event.RecurrenceID.IsZero():
+			suffix = event.RecurrenceID.Format("20060102")
+		case event.Recurrence != nil:
+			suffix = startDate.Format("20060102")
+		}
+		endDate := startDate.AddDate(0, 0, days)
+		rule := newRule(suffix)
+		rule.Kind = models.RecurrenceDateRange
+		rule.StartDate = &startDate
+		rule.EndDate = &endDate
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// recurrenceError explica por qué no se importó un evento con la RRULE rrule
+func recurrenceError(c *gin.Context, err error, rrule string) string {
+	switch {
+	case errors.Is(err, ical.ErrUnboundedRecurrence):
+		return translate(c, "Recurrence needs COUNT or UNTIL unless it is yearly: %s", rrule)
+	case errors.Is(err, ical.ErrTooManyOccurrences):
+		return translate(c, "Recurrence has too many occurrences: %s", rrule)
+	default:
+		return translate(c, "Unsupported recurrence rule: %s", rrule)
+	}
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services/ical"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func parseHolidays(t *testing.T, events ...string) []ical.Event {
+	t.Helper()
+	calendar := "BEGIN:VCALENDAR\r\n" + strings.Join(events, "\r\n") + "\r\nEND:VCALENDAR\r\n"
+	parsed, err := ical.Parse(strings.NewReader(calendar))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return parsed
+}
+
+func holidayEvent(uid, start, end, rrule string) string {
+	event := "BEGIN:VEVENT\r\nUID:" + uid + "\r\nSUMMARY:Feriado\r\nDTSTART;VALUE=DATE:" + start + "\r\nDTEND;VALUE=DATE:" + end
+	if rrule != "" {
+		event += "\r\nRRULE:" + rrule
+	}
+	return event + "\r\nEND:VEVENT"
+}
+
+// describeRule resume una regla importada como "UID kind fechas"
+func describeRule(rule models.RecurringRule) string {
+	switch rule.Kind {
+	case models.RecurrenceYearly:
+		return rule.ExternalUID + " yearly " + time.Date(2000, time.Month(rule.Month), rule.Day, 0, 0, 0, 0, time.UTC).Format("01-02")
+	default:
+		return rule.ExternalUID + " range " + rule.StartDate.Format("2006-01-02") + ".." + rule.EndDate.Format("2006-01-02")
+	}
+}
+
+func TestHolidayRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "single day",
+			event: holidayEvent("a", "20250227", "20250228", ""),
+			want:  []string{"a range 2025-02-27..2025-02-27"},
+		},
+		{
+			name:  "yearly without end",
+			event: holidayEvent("a", "20250227", "20250228", "FREQ=YEARLY"),
+			want:  []string{"a yearly 02-27"},
+		},
+		{
+			name:  "multi-day yearly without end",
+			event: holidayEvent("a", "20251224", "20251226", "FREQ=YEARLY"),
+			want:  []string{"a/1224 yearly 12-24", "a/1225 yearly 12-25"},
+		},
+		{
+			name:  "yearly with count",
+			event: holidayEvent("a", "20251224", "20251226", "FREQ=YEARLY;COUNT=2"),
+			want:  []string{"a/20251224 range 2025-12-24..2025-12-25", "a/20261224 range 2026-12-24..2026-12-25"},
+		},
+		{
+			name:  "weekly with until",
+			event: holidayEvent("a", "20250303", "20250304", "FREQ=WEEKLY;UNTIL=20250310"),
+			want:  []string{"a/20250303 range 2025-03-03..2025-03-03", "a/20250310 range 2025-03-10..2025-03-10"},
+		},
+		{
+			name:    "monthly without end",
+			event:   holidayEvent("a", "20250303", "20250304", "FREQ=MONTHLY"),
+			wantErr: ical.ErrUnboundedRecurrence,
+		},
+		{
+			name:    "yearly every two years without end",
+			event:   holidayEvent("a", "20250303", "20250304", "FREQ=YEARLY;INTERVAL=2"),
+			wantErr: ical.ErrUnsupportedRecurrence,
+		},
+		{
+			name:    "yearly by weekday",
+			event:   holidayEvent("a", "20251127", "20251128", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH"),
+			wantErr: ical.ErrUnsupportedRecurrence,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := holidayRules(parseHolidays(t, tt.event)[0])
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("holidayRules() error = %v, want %v", err, tt.wantErr)
+			}
+			got := []string{}
+			for _, rule := range rules {
+				if !rule.AllDay || rule.Mode != models.RuleModeBlock {
+					t.Errorf("rule %s does not block the whole day", rule.ExternalUID)
+				}
+				got = append(got, describeRule(rule))
+			}
+			if tt.wantErr == nil && strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Fatalf("holidayRules() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestCreateRecurringRuleKeepsAllDayFalse(t *testing.T) {
+	setupTestDB(t)
+
+	router := gin.New()
+	router.POST("/admin/recurring-rules", CreateRecurringRule)
+
+	tests := []struct {
+		name       string
+		allDay     string
+		wantAllDay bool
+	}{
+		{name: "explicit false", allDay: `"allDay": false,`, wantAllDay: false},
+		{name: "explicit true", allDay: `"allDay": true,`, wantAllDay: true},
+		{name: "omitted defaults to true", allDay: "", wantAllDay: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"name": "Test ` + tt.name + `", "kind": "yearly", "month": 2, "day": 27, ` + tt.allDay + ` "unavailableHours": [9, 10]}`
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/recurring-rules", strings.NewReader(body)))
+			if w.Code != http.StatusCreated {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body.String())
+			}
+
+			var created struct {
+				Rule models.RecurringRule `json:"rule"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			t.Cleanup(func() { initializers.DB.Unscoped().Delete(&models.RecurringRule{}, created.Rule.ID) })
+
+			var stored models.RecurringRule
+			if err := initializers.DB.First(&stored, created.Rule.ID).Error; err != nil {
+				t.Fatalf("read rule back: %v", err)
+			}
+			if stored.AllDay != tt.wantAllDay {
+				t.Fatalf("stored AllDay = %v, want %v", stored.AllDay, tt.wantAllDay)
+			}
+		})
+	}
+}
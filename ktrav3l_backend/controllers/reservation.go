@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services/availability"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// slotLockNamespace separa los advisory locks de reservas de cualquier otro uso
+const slotLockNamespace = 4201
+
+// withDayLock ejecuta fn en una transacción que tiene tomada la agenda del día.
+// Las reservas concurrentes de una misma fecha esperan su turno, de modo que el
+// cupo verificado dentro de fn sigue siendo válido al escribir.
+func withDayLock(date time.Time, fn func(tx *gorm.DB) error) error {
+	return initializers.DB.Transaction(func(tx *gorm.DB) error {
+		key := date.Year()*10000 + int(date.Month())*100 + date.Day()
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?::int, ?::int)", slotLockNamespace, key).Error; err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// moveAppointmentTo mueve la cita a date/start conservando su duración. Verifica reglas,
+// horario y cupo y guarda con la agenda del día bloqueada (sin contar la propia cita);
+// el asesor se conserva si sigue libre y si no se asigna otro.
+func moveAppointmentTo(appointment *models.Appointment, date time.Time, start int, opts availability.Options) error {
+	end := start + (appointment.EndMinute - appointment.StartMinute)
+
+	return withDayLock(date, func(tx *gorm.DB) error {
+		schedule := availability.Load(tx, date, appointment.ID.String())
+		if err := schedule.IsSlotAvailable(start, end, appointment.AppointmentType, opts); err != nil {
+			return err
+		}
+
+		if appointment.AdvisorID != nil && !schedule.AdvisorFree(*appointment.AdvisorID, start, end) {
+			advisorID, err := schedule.PickAdvisor("", start, end)
+			if err != nil {
+				return err
+			}
+			appointment.AdvisorID = advisorID
+		}
+
+		appointment.AppointmentDate = models.NewDateOnly(date)
+		appointment.StartMinute = start
+		appointment.EndMinute = end
+		appointment.SlotCapacity = schedule.Capacity()
+		appointment.BookedWithOverride = opts.Override
+		appointment.CalendarSequence++
+		return tx.Save(appointment).Error
+	})
+}
+
+// respondReservationError responde según el motivo por el que falló una reserva
+func respondReservationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, availability.ErrAdvisorNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, err.Error())})
+	case errors.Is(err, availability.ErrDayBlocked),
+		errors.Is(err, availability.ErrSlotBlocked),
+		errors.Is(err, availability.ErrPastTime),
+		errors.Is(err, availability.ErrOutsideBookingWindow),
+		errors.Is(err, availability.ErrSlotFull),
+		errors.Is(err, availability.ErrAdvisorUnavailable):
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, err.Error())})
+	case isSlotConflict(err):
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, availability.ErrSlotFull.Error())})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, fallback)})
+	}
+}
+
+// respondTransitionError responde 409 con los estados permitidos cuando la tabla de
+// estados de models no admite el cambio pedido
+func respondTransitionError(c *gin.Context, err error) {
+	var transitionErr *models.TransitionError
+	if errors.As(err, &transitionErr) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":              translate(c, "Cannot change appointment status from %s to %s", transitionErr.From, transitionErr.To),
+			"currentStatus":      transitionErr.From,
+			"allowedTransitions": transitionErr.Allowed,
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error updating appointment")})
+}
+
+// isSlotConflict indica si la base de datos rechazó la escritura por la
+// restricción de solapamiento de citas de un mismo asesor o por el cupo de la franja
+func isSlotConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23P01" // exclusion_violation
+}
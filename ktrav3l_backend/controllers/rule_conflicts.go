@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services/availability"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	uuid "github.com/google/uuid"
+)
+
+const (
+	conflictActionReject = "reject"
+	conflictActionMove   = "move"
+
+	// conflictMoveSearchDays es cuántos días hacia adelante se busca una franja libre al mover
+	conflictMoveSearchDays = 30
+)
+
+// conflictScope limita la búsqueda de conflictos a lo que una regla puede afectar
+type conflictScope struct {
+	DayOfWeek *int       // Solo las fechas de ese día de semana; nil = todas
+	AdvisorID *uuid.UUID // Solo las citas de ese asesor; nil = todas (regla general)
+}
+
+// scopeOf devuelve el alcance de una regla: su día de semana y su asesor
+func scopeOf(rule models.AvailabilityRule) conflictScope {
+	return conflictScope{DayOfWeek: rule.DayOfWeek, AdvisorID: rule.AdvisorID}
+}
+
+// ruleConflicts devuelve las citas pendientes o aprobadas entre from y to (sin incluir
+// fechas pasadas) dentro de scope que las reglas vigentes ya no permiten
+func ruleConflicts(from, to time.Time, scope conflictScope) []models.Appointment {
+	now := config.Env.BusinessNow()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if from.Before(today) {
+		from = today
+	}
+	if to.Before(from) {
+		return []models.Appointment{}
+	}
+
+	var ids []string
+	schedules := availability.LoadRange(initializers.DB, from, to, "")
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if scope.DayOfWeek != nil && int(day.Weekday()) != *scope.DayOfWeek {
+			continue
+		}
+		for _, appointment := range schedules[day.Format("2006-01-02")].Conflicts() {
+			if scope.AdvisorID != nil && (appointment.AdvisorID == nil || *appointment.AdvisorID != *scope.AdvisorID) {
+				continue
+			}
+			ids = append(ids, appointment.ID.String())
+		}
+	}
+
+	conflicts := []models.Appointment{}
+	if len(ids) > 0 {
+		initializers.DB.Preload("AppointmentType").Preload("BankAccount").
+			Where("id IN ?", ids).
+			Order("appointment_date ASC, start_minute ASC").
+			Find(&conflicts)
+	}
+	return conflicts
+}
+
+// weekdayRuleConflicts revisa las citas futuras afectadas por una regla de día de semana,
+// hasta la última fecha con citas pendientes o aprobadas
+func weekdayRuleConflicts(rule models.AvailabilityRule) []models.Appointment {
+	var lastDate *time.Time
+	initializers.DB.Model(&models.Appointment{}).
+		Where("status IN ?", models.ActiveStatuses).
+		Select("MAX(appointment_date)").
+		Scan(&lastDate)
+	if lastDate == nil {
+		return []models.Appointment{}
+	}
+	return ruleConflicts(time.Time{}, *lastDate, scopeOf(rule))
+}
+
+// resolveRuleConflicts rechaza o mueve a la siguiente franja libre las citas en conflicto
+// y envía el email correspondiente. Las reservadas con Override se dejan como están.
+// Devuelve el resultado de cada cita.
+func resolveRuleConflicts(c *gin.Context, conflicts []models.Appointment, action, adminReason string) []gin.H {
+	results := []gin.H{}
+	for _, appointment := range conflicts {
+		result := appointmentSummary(appointment)
+		before := appointment
+
+		// Sin motivo del admin, el cliente recibe el genérico en su idioma
+		reason := adminReason
+		if reason == "" {
+			reason = i18n.T(appointment.Locale.OrDefault(), "email.rejected.ruleConflict")
+		}
+
+		// El admin la reservó sabiendo que las reglas no la permitían: solo se informa
+		if appointment.BookedWithOverride {
+			result["result"] = "kept"
+			results = append(results, result)
+			continue
+		}
+
+		switch action {
+		case conflictActionReject:
+			// Una cita aprobada ya no se puede rechazar: se cancela con el mismo motivo
+			next := models.StatusRejected
+			if appointment.Status == models.StatusApproved {
+				next = models.StatusCancelled
+			}
+			if appointment.TransitionTo(next) != nil {
+				result["result"] = "unresolved"
+				break
+			}
+			appointment.RejectionReason = reason
+			appointment.CalendarSequence++
+			if err := initializers.DB.Save(&appointment).Error; err != nil {
+				result["result"] = "unresolved"
+				break
+			}
+			event := models.EventRejected
+			if next == models.StatusCancelled {
+				event = models.EventCancelled
+			}
+			recordAppointmentEvent(c, event, before, appointment, reason)
+			// La cita aprobada se cancela: el cliente recibe la cancelación y el CANCEL del .ics
+			if next == models.StatusCancelled {
+				go sendCancelledEmail(appointment, true)
+			} else {
+				go sendRejectedEmail(appointment, reason)
+			}
+			result["result"] = string(next)
+
+		case conflictActionMove:
+			oldDate := appointment.AppointmentDate.Time
+			oldStart := appointment.StartMinute
+			date, start, found := availability.NextAvailable(initializers.DB, appointment, conflictMoveSearchDays)
+			if !found || moveAppointmentTo(&appointment, date, start, availability.Options{}) != nil {
+				result["result"] = "unresolved"
+				break
+			}
+			recordAppointmentEvent(c, models.EventMoved, before, appointment, reason)
+			go SendAppointmentMovedEmail(appointment, oldDate, oldStart)
+			result["result"] = "moved"
+			result["newDate"] = appointment.AppointmentDate
+			result["newStartTime"] = appointment.StartTime()
+			result["newEndTime"] = appointment.EndTime()
+		}
+
+		results = append(results, result)
+	}
+
+	// Las franjas que dejaron las citas rechazadas o movidas se ofrecen a la lista de espera
+	if len(conflicts) > 0 && action != "" {
+		go processWaitlist()
+	}
+	return results
+}
+
+// conflictResponse agrega al cuerpo de respuesta de una regla las citas en conflicto y,
+// si se pidió una acción, el resultado de resolverlas
+func conflictResponse(c *gin.Context, response gin.H, conflicts []models.Appointment, action, reason string) gin.H {
+	summaries := []gin.H{}
+	for _, appointment := range conflicts {
+		summaries = append(summaries, appointmentSummary(appointment))
+	}
+	response["conflicts"] = summaries
+
+	if action != "" {
+		response["resolved"] = resolveRuleConflicts(c, conflicts, action, reason)
+	}
+	return response
+}
+
+// appointmentSummary resume una cita para listados de conflictos o citas afectadas
+func appointmentSummary(appointment models.Appointment) gin.H {
+	return gin.H{
+		"id":              appointment.ID,
+		"shortId":         appointment.ShortID,
+		"firstName":       appointment.FirstName,
+		"lastName":        appointment.LastName,
+		"appointmentDate": appointment.AppointmentDate,
+		"startTime":       appointment.StartTime(),
+		"endTime":         appointment.EndTime(),
+		"appointmentType": appointment.AppointmentType.Name,
+		"status":          appointment.Status,
+		"override":        appointment.BookedWithOverride,
+	}
+}
+
+// sendRejectedEmail envía el email de rechazo de una cita pendiente sin bloquear la respuesta
+func sendRejectedEmail(appointment models.Appointment, reason string) {
+	if err := emailService.SendAppointmentRejected(&appointment, reason, false); err != nil {
+		println("Error sending rejection email:", err.Error())
+	}
+}
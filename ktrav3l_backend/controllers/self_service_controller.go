@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services/availability"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CancelAppointmentByClient cancela una cita pendiente o aprobada con el token de gestión
+// que el cliente recibió en el email de confirmación
+func CancelAppointmentByClient(c *gin.Context) {
+	var body struct {
+		Token  string `json:"token" binding:"required"`
+		Reason string `json:"reason"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data")})
+		return
+	}
+
+	appointment, ok := loadManagedAppointment(c, body.Token)
+	if !ok {
+		return
+	}
+
+	before := appointment
+	if err := appointment.TransitionTo(models.StatusCancelled); err != nil {
+		respondTransitionError(c, err)
+		return
+	}
+	appointment.CalendarSequence++
+	if err := initializers.DB.Save(&appointment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error cancelling appointment")})
+		return
+	}
+	recordAppointmentEvent(c, models.EventCancelled, before, appointment, body.Reason)
+
+	// El aviso al admin va en el idioma del negocio, no en el del cliente
+	detail := i18n.T(i18n.Default, "email.clientChange.cancelledDetail")
+	if body.Reason != "" {
+		detail += " " + i18n.T(i18n.Default, "email.clientChange.reason", body.Reason)
+	}
+	go sendClientChangeNotification(appointment, i18n.T(i18n.Default, "email.clientChange.cancelled"), detail)
+
+	// Confirmación al cliente; si la cita estaba aprobada, la invitación CANCEL la quita de su calendario
+	go sendCancelledEmail(appointment, before.Status == models.StatusApproved)
+
+	// La franja liberada se ofrece a la lista de espera
+	go processWaitlist()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Appointment cancelled successfully",
+		"shortID": appointment.ShortID,
+		"status":  appointment.Status,
+	})
+}
+
+// RescheduleAppointmentByClient mueve una cita pendiente o aprobada a otra franja libre con
+// el token de gestión. Se aplican las mismas reglas y ventana de reserva que al reservar.
+func RescheduleAppointmentByClient(c *gin.Context) {
+	var body struct {
+		Token   string `json:"token" binding:"required"`
+		NewDate string `json:"newDate" binding:"required"` // YYYY-MM-DD
+		NewTime string `json:"newTime"`                    // HH:MM
+		NewHour *int   `json:"newHour"`                    // Compatibilidad: hora entera 0-23
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data")})
+		return
+	}
+
+	newDate, err := time.Parse("2006-01-02", body.NewDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid date format")})
+		return
+	}
+	newStart, err := parseStartMinute(body.NewTime, body.NewHour)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid hour")})
+		return
+	}
+
+	appointment, ok := loadManagedAppointment(c, body.Token)
+	if !ok {
+		return
+	}
+
+	if newStart+(appointment.EndMinute-appointment.StartMinute) > models.MinutesPerDay {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Appointment must end on the same day")})
+		return
+	}
+
+	before := appointment
+	oldDate := appointment.AppointmentDate.Time
+	oldStart := appointment.StartMinute
+	if err := moveAppointmentTo(&appointment, newDate, newStart, availability.Options{}); err != nil {
+		respondReservationError(c, err, "Error rescheduling appointment")
+		return
+	}
+	recordAppointmentEvent(c, models.EventMoved, before, appointment, "")
+
+	go SendAppointmentMovedEmail(appointment, oldDate, oldStart)
+	go sendClientChangeNotification(appointment, i18n.T(i18n.Default, "email.clientChange.rescheduled"),
+		i18n.T(i18n.Default, "email.clientChange.rescheduledDetail", i18n.FormatDate(i18n.Default, oldDate), i18n.FormatTime(i18n.Default, oldStart)))
+
+	// La franja anterior quedó libre para la lista de espera
+	go processWaitlist()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "Appointment rescheduled successfully",
+		"shortID":         appointment.ShortID,
+		"appointmentDate": appointment.AppointmentDate,
+		"startTime":       appointment.StartTime(),
+		"endTime":         appointment.EndTime(),
+		"status":          appointment.Status,
+	})
+}
+
+// loadManagedAppointment busca la cita del código corto y verifica el token de gestión,
+// que siga pendiente o aprobada y que falte más que SELF_SERVICE_CUTOFF_MINUTES para su
+// inicio. Si algo falla ya respondió al cliente y devuelve false.
+func loadManagedAppointment(c *gin.Context, token string) (models.Appointment, bool) {
+	var appointment models.Appointment
+	err := initializers.DB.Preload("AppointmentType").Where("short_id = ?", models.NormalizeLookupCode(c.Param("shortID"))).First(&appointment).Error
+	if err != nil || appointment.ManageToken == "" ||
+		subtle.ConstantTimeCompare([]byte(appointment.ManageToken), []byte(token)) != 1 {
+		// Misma respuesta para código o token inválidos, para no revelar qué códigos existen
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment not found")})
+		return appointment, false
+	}
+
+	if !appointment.Status.IsActive() {
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, "Only pending or approved appointments can be changed")})
+		return appointment, false
+	}
+
+	cutoff := time.Duration(config.Env.SelfServiceCutoffMinutes) * time.Minute
+	if time.Until(appointment.StartsAt(config.Env.BusinessLocation)) < cutoff {
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, "Appointments can only be changed up to %d minutes before they start", config.Env.SelfServiceCutoffMinutes)})
+		return appointment, false
+	}
+
+	return appointment, true
+}
+
+// sendCancelledEmail confirma la cancelación al cliente sin bloquear la respuesta
+func sendCancelledEmail(appointment models.Appointment, wasApproved bool) {
+	if err := emailService.SendAppointmentCancelled(&appointment, wasApproved); err != nil {
+		fmt.Println("Error sending cancellation email:", err)
+	}
+}
+
+// sendClientChangeNotification avisa al admin del cambio sin bloquear la respuesta
+func sendClientChangeNotification(appointment models.Appointment, change, detail string) {
+	if err := emailService.SendClientChangeNotification(&appointment, change, detail); err != nil {
+		fmt.Println("Error sending client change notification:", err)
+	}
+}
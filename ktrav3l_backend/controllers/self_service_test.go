@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCancelAppointmentByClientSendsCancelInvite(t *testing.T) {
+	setupTestDB(t)
+	memory := useMemoryMailer(t)
+	appointmentType := testAppointmentType(t)
+	date := testBookingDate(t, 3)
+
+	appointment := models.Appointment{
+		FirstName:         "Cliente",
+		LastName:          "Aprobado",
+		Email:             "aprobado@ktravel.test",
+		PhoneNumber:       "8095551234",
+		AppointmentDate:   models.NewDateOnly(date),
+		StartMinute:       600,
+		EndMinute:         660,
+		AppointmentTypeID: appointmentType.ID,
+		BankTransfer:      models.BankPopular,
+		Status:            models.StatusApproved,
+		SlotCapacity:      1,
+	}
+	if err := initializers.DB.Create(&appointment).Error; err != nil {
+		t.Fatalf("create appointment: %v", err)
+	}
+
+	router := gin.New()
+	router.POST("/appointments/short/:shortID/cancel", CancelAppointmentByClient)
+	body, _ := json.Marshal(gin.H{"token": appointment.ManageToken})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/appointments/short/"+appointment.ShortID+"/cancel", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	// La confirmación al cliente y el aviso al admin
+	waitForEmails(t, memory, 2)
+
+	var stored models.Appointment
+	initializers.DB.First(&stored, "id = ?", appointment.ID)
+	if stored.Status != models.StatusCancelled || stored.CalendarSequence != 1 {
+		t.Fatalf("status = %s, sequence = %d; want cancelled and 1", stored.Status, stored.CalendarSequence)
+	}
+
+	sent := memory.SentTo(appointment.Email)
+	if len(sent) != 1 {
+		t.Fatalf("sent %d emails to the client, want 1", len(sent))
+	}
+	attachments := sent[0].Attachments
+	if len(attachments) != 1 || !strings.Contains(attachments[0].ContentType, "method=CANCEL") ||
+		!strings.Contains(string(attachments[0].Content), "SEQUENCE:1") {
+		t.Fatalf("attachments = %+v, want a CANCEL invite with sequence 1", attachments)
+	}
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services/availability"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// errTooManyHolds indica que la IP ya tiene MAX_HOLDS_PER_CLIENT reservas temporales vigentes
+var errTooManyHolds = errors.New("Too many active holds, release one or wait for it to expire")
+
+// CreateSlotHold reserva una franja durante SLOT_HOLD_MINUTES y devuelve un token
+// que el cliente envía luego como holdToken al crear la cita. Cada IP puede tener a lo
+// sumo MAX_HOLDS_PER_CLIENT reservas vigentes, para que nadie acapare la agenda.
+func CreateSlotHold(c *gin.Context) {
+	var body struct {
+		AppointmentDate   string `json:"appointmentDate" binding:"required"`
+		AppointmentTime   string `json:"appointmentTime"` // HH:MM
+		AppointmentHour   *int   `json:"appointmentHour"` // Compatibilidad: hora entera 0-23
+		AppointmentTypeID uint   `json:"appointmentTypeID" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data")})
+		return
+	}
+
+	parsedDate, err := time.Parse("2006-01-02", body.AppointmentDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid date format")})
+		return
+	}
+	appointmentDate := models.NewDateOnly(parsedDate)
+
+	startMinute, err := parseStartMinute(body.AppointmentTime, body.AppointmentHour)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid hour")})
+		return
+	}
+
+	var appointmentType models.AppointmentType
+	if err := initializers.DB.First(&appointmentType, body.AppointmentTypeID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
+		return
+	}
+
+	if !appointmentType.Visible {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Appointment type not available")})
+		return
+	}
+
+	endMinute := startMinute + appointmentType.DurationMinutes
+	if endMinute > models.MinutesPerDay {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Appointment must end on the same day")})
+		return
+	}
+
+	hold := models.SlotHold{
+		Token:             uuid.NewString(),
+		AppointmentDate:   appointmentDate,
+		StartMinute:       startMinute,
+		EndMinute:         endMinute,
+		AppointmentTypeID: appointmentType.ID,
+		ExpiresAt:         time.Now().Add(time.Duration(config.Env.SlotHoldMinutes) * time.Minute),
+		ClientIP:          c.ClientIP(),
+	}
+
+	err = withDayLock(parsedDate, func(tx *gorm.DB) error {
+		// Las reservas de otras fechas no toman el lock del día: serializar también por IP
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "slot-hold:"+hold.ClientIP).Error; err != nil {
+			return err
+		}
+		var active int64
+		if err := tx.Model(&models.SlotHold{}).Where("client_ip = ? AND expires_at > ?", hold.ClientIP, time.Now()).Count(&active).Error; err != nil {
+			return err
+		}
+		if active >= int64(config.Env.MaxHoldsPerClient) {
+			return errTooManyHolds
+		}
+
+		schedule := availability.Load(tx, parsedDate, "")
+		if err := schedule.IsSlotAvailable(startMinute, endMinute, appointmentType, availability.Options{}); err != nil {
+			return err
+		}
+		return tx.Create(&hold).Error
+	})
+	if errors.Is(err, errTooManyHolds) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": translate(c, err.Error())})
+		return
+	}
+	if err != nil {
+		respondReservationError(c, err, "Error holding time slot")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"holdToken":       hold.Token,
+		"appointmentDate": hold.AppointmentDate,
+		"startTime":       models.FormatMinutes(hold.StartMinute),
+		"endTime":         models.FormatMinutes(hold.EndMinute),
+		"expiresAt":       hold.ExpiresAt,
+	})
+}
+
+// ReleaseSlotHold libera una reserva temporal antes de que expire
+func ReleaseSlotHold(c *gin.Context) {
+	token := c.Param("token")
+
+	result := initializers.DB.Unscoped().Where("token = ?", token).Delete(&models.SlotHold{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error releasing hold")})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Hold not found")})
+		return
+	}
+
+	// Si era una franja ofrecida a la lista de espera, el cliente la rechazó:
+	// se ofrece a la siguiente persona
+	result = initializers.DB.Model(&models.WaitlistEntry{}).
+		Where("claim_token = ? AND status = ?", token, models.WaitlistNotified).
+		Update("status", models.WaitlistExpired)
+	if result.RowsAffected > 0 {
+		go processWaitlist()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Hold released",
+	})
+}
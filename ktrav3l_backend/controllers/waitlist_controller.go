@@ -0,0 +1,346 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services/availability"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// waitlistMutex evita que dos pasadas de la lista de espera ofrezcan franjas a la vez
+var waitlistMutex sync.Mutex
+
+// JoinWaitlist registra a un cliente en la lista de espera de un tipo de cita entre dos fechas
+func JoinWaitlist(c *gin.Context) {
+	var body struct {
+		FirstName         string `json:"firstName" binding:"required"`
+		LastName          string `json:"lastName" binding:"required"`
+		Email             string `json:"email" binding:"required,email"`
+		PhoneNumber       string `json:"phoneNumber" binding:"required"`
+		AppointmentTypeID uint   `json:"appointmentTypeID" binding:"required"`
+		FromDate          string `json:"fromDate" binding:"required"` // YYYY-MM-DD
+		ToDate            string `json:"toDate" binding:"required"`   // YYYY-MM-DD, inclusive
+		Locale            string `json:"locale"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid data")})
+		return
+	}
+
+	cleanPhone := regexp.MustCompile(`\D`).ReplaceAllString(body.PhoneNumber, "")
+	if len(cleanPhone) != 10 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "El teléfono debe tener 10 dígitos")})
+		return
+	}
+
+	fromDate, err := time.Parse("2006-01-02", body.FromDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid fromDate")})
+		return
+	}
+	toDate, err := time.Parse("2006-01-02", body.ToDate)
+	if err != nil || toDate.Before(fromDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid toDate")})
+		return
+	}
+	if toDate.Sub(fromDate) > maxAvailabilityRangeDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Date range cannot exceed %d days", maxAvailabilityRangeDays)})
+		return
+	}
+	now := config.Env.BusinessNow()
+	if toDate.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Date range is in the past")})
+		return
+	}
+
+	var appointmentType models.AppointmentType
+	if err := initializers.DB.First(&appointmentType, body.AppointmentTypeID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Appointment type not found")})
+		return
+	}
+	if !appointmentType.Visible {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Appointment type not available")})
+		return
+	}
+
+	// Un email solo puede estar una vez en la lista de cada tipo de cita
+	email := strings.ToLower(strings.TrimSpace(body.Email))
+	var existing int64
+	initializers.DB.Model(&models.WaitlistEntry{}).
+		Where("LOWER(email) = ? AND appointment_type_id = ? AND status IN ?", email, appointmentType.ID, models.ActiveWaitlistStatuses).
+		Count(&existing)
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, "This email is already on the waitlist for this appointment type")})
+		return
+	}
+
+	entry := models.WaitlistEntry{
+		FirstName:         body.FirstName,
+		LastName:          body.LastName,
+		Email:             email,
+		PhoneNumber:       cleanPhone,
+		AppointmentTypeID: appointmentType.ID,
+		AppointmentType:   appointmentType,
+		FromDate:          models.NewDateOnly(fromDate),
+		ToDate:            models.NewDateOnly(toDate),
+		Status:            models.WaitlistUnconfirmed,
+		ConfirmToken:      uuid.NewString(),
+		Locale:            bookingLocale(c, body.Locale),
+	}
+	if err := initializers.DB.Omit("AppointmentType").Create(&entry).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error joining waitlist")})
+		return
+	}
+
+	// No se le ofrecen franjas hasta que confirme que el email es suyo
+	go sendWaitlistConfirmation(entry)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Check your email to confirm your waitlist entry",
+		"id":      entry.ID,
+		"status":  entry.Status,
+	})
+}
+
+// ConfirmWaitlist confirma el email de una entrada de la lista de espera con el token
+// del email de confirmación; desde ese momento se le ofrecen franjas
+func ConfirmWaitlist(c *gin.Context) {
+	token := c.Param("token")
+
+	var entry models.WaitlistEntry
+	if err := initializers.DB.Where("confirm_token = ? AND confirm_token <> '' AND status = ?", token, models.WaitlistUnconfirmed).First(&entry).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Confirmation not found or already used")})
+		return
+	}
+
+	err := initializers.DB.Model(&entry).Updates(map[string]interface{}{
+		"status":        models.WaitlistWaiting,
+		"confirm_token": "",
+	}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error joining waitlist")})
+		return
+	}
+
+	// Si ya hay una franja libre en su rango, se le ofrece de inmediato
+	go processWaitlist()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Waitlist entry confirmed",
+		"id":      entry.ID,
+		"status":  models.WaitlistWaiting,
+	})
+}
+
+// LeaveWaitlist saca al cliente de la lista de espera y libera la franja que tuviera ofrecida
+func LeaveWaitlist(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": translate(c, "Invalid waitlist entry ID")})
+		return
+	}
+
+	var entry models.WaitlistEntry
+	if err := initializers.DB.First(&entry, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Waitlist entry not found")})
+		return
+	}
+	if entry.Status != models.WaitlistUnconfirmed && entry.Status != models.WaitlistWaiting && entry.Status != models.WaitlistNotified {
+		c.JSON(http.StatusConflict, gin.H{"error": translate(c, "Waitlist entry is no longer active")})
+		return
+	}
+
+	err = initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if entry.ClaimToken != "" {
+			if err := tx.Unscoped().Where("token = ?", entry.ClaimToken).Delete(&models.SlotHold{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&entry).Update("status", models.WaitlistCancelled).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": translate(c, "Error leaving waitlist")})
+		return
+	}
+
+	// Si tenía una franja ofrecida, pasa a la siguiente persona
+	if entry.Status == models.WaitlistNotified {
+		go processWaitlist()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Removed from waitlist",
+	})
+}
+
+// GetWaitlistClaim devuelve la franja ofrecida por un enlace de la lista de espera para
+// que el frontend precargue la reserva; el token se envía luego como holdToken
+func GetWaitlistClaim(c *gin.Context) {
+	token := c.Param("token")
+
+	var entry models.WaitlistEntry
+	if err := initializers.DB.Preload("AppointmentType").Where("claim_token = ? AND claim_token <> ''", token).First(&entry).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": translate(c, "Claim not found")})
+		return
+	}
+
+	var hold models.SlotHold
+	if entry.Status != models.WaitlistNotified ||
+		initializers.DB.Where("token = ? AND expires_at > ?", token, time.Now()).First(&hold).Error != nil {
+		c.JSON(http.StatusGone, gin.H{"error": translate(c, "Claim expired or already used")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"holdToken":         hold.Token,
+		"appointmentDate":   hold.AppointmentDate,
+		"startTime":         models.FormatMinutes(hold.StartMinute),
+		"endTime":           models.FormatMinutes(hold.EndMinute),
+		"appointmentTypeID": hold.AppointmentTypeID,
+		"appointmentType":   entry.AppointmentType.Name,
+		"expiresAt":         hold.ExpiresAt,
+		"firstName":         entry.FirstName,
+		"lastName":          entry.LastName,
+		"email":             entry.Email,
+		"phoneNumber":       entry.PhoneNumber,
+	})
+}
+
+// GetWaitlist lista la lista de espera para el admin, opcionalmente filtrada por estado
+func GetWaitlist(c *gin.Context) {
+	query := initializers.DB.Preload("AppointmentType").Order("created_at ASC")
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var entries []models.WaitlistEntry
+	query.Find(&entries)
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+	})
+}
+
+// StartWaitlistNotifier revisa la lista de espera cada interval: vence las ofertas no
+// tomadas y ofrece las franjas libres a los siguientes clientes
+func StartWaitlistNotifier(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for range ticker.C {
+			processWaitlist()
+		}
+	}()
+}
+
+// processWaitlist ofrece franjas libres a la lista de espera en orden de llegada. Cada
+// franja ofrecida queda reservada (SlotHold) para ese cliente, de modo que el siguiente
+// en la lista solo recibe otra franja si queda lugar.
+func processWaitlist() {
+	waitlistMutex.Lock()
+	defer waitlistMutex.Unlock()
+
+	now := config.Env.BusinessNow()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Ofertas no tomadas a tiempo y esperas cuyo rango ya pasó
+	initializers.DB.Model(&models.WaitlistEntry{}).
+		Where("status = ? AND claim_expires_at <= ?", models.WaitlistNotified, time.Now()).
+		Update("status", models.WaitlistExpired)
+	initializers.DB.Model(&models.WaitlistEntry{}).
+		Where("status IN ? AND to_date < ?", []models.WaitlistStatus{models.WaitlistUnconfirmed, models.WaitlistWaiting}, today).
+		Update("status", models.WaitlistExpired)
+
+	var entries []models.WaitlistEntry
+	initializers.DB.Preload("AppointmentType").
+		Where("status = ?", models.WaitlistWaiting).
+		Order("created_at ASC").
+		Find(&entries)
+
+	for _, entry := range entries {
+		if err := offerWaitlistSlot(entry, today); err != nil {
+			fmt.Println("Error offering waitlist slot:", err)
+		}
+	}
+}
+
+// offerWaitlistSlot busca la primera franja libre en el rango del cliente, se la reserva
+// durante WAITLIST_CLAIM_MINUTES y le envía el enlace para tomarla
+func offerWaitlistSlot(entry models.WaitlistEntry, today time.Time) error {
+	from := entry.FromDate.Time
+	if from.Before(today) {
+		from = today
+	}
+	to := entry.ToDate.Time
+	if to.Before(from) || !entry.AppointmentType.Visible {
+		return nil
+	}
+
+	appointmentType := entry.AppointmentType
+	schedules := availability.LoadRange(initializers.DB, from, to, "")
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		slots, _ := schedules[day.Format("2006-01-02")].AvailableSlots(appointmentType, appointmentType.DurationMinutes)
+		for _, slot := range slots {
+			hold := models.SlotHold{
+				Token:             uuid.NewString(),
+				AppointmentDate:   models.NewDateOnly(day),
+				StartMinute:       slot.Start,
+				EndMinute:         slot.End,
+				AppointmentTypeID: appointmentType.ID,
+				ExpiresAt:         time.Now().Add(time.Duration(config.Env.WaitlistClaimMinutes) * time.Minute),
+			}
+
+			err := withDayLock(day, func(tx *gorm.DB) error {
+				schedule := availability.Load(tx, day, "")
+				if err := schedule.IsSlotAvailable(slot.Start, slot.End, appointmentType, availability.Options{}); err != nil {
+					return err
+				}
+				if err := tx.Create(&hold).Error; err != nil {
+					return err
+				}
+				notifiedAt := time.Now()
+				return tx.Model(&entry).Updates(map[string]interface{}{
+					"status":           models.WaitlistNotified,
+					"claim_token":      hold.Token,
+					"claim_expires_at": hold.ExpiresAt,
+					"notified_at":      notifiedAt,
+				}).Error
+			})
+			if err != nil {
+				// La franja se ocupó mientras tanto: probar la siguiente
+				continue
+			}
+
+			go sendWaitlistEmail(entry, hold)
+			return nil
+		}
+	}
+	return nil
+}
+
+// sendWaitlistConfirmation envía al cliente el enlace para confirmar su email
+func sendWaitlistConfirmation(entry models.WaitlistEntry) {
+	confirmURL := config.Env.FrontendURL + "/waitlist/confirm?token=" + url.QueryEscape(entry.ConfirmToken) + "&lang=" + string(entry.Locale.OrDefault())
+	if err := emailService.SendWaitlistConfirmation(&entry, confirmURL); err != nil {
+		fmt.Println("Error sending waitlist confirmation email:", err)
+	}
+}
+
+// sendWaitlistEmail envía al cliente el enlace para tomar la franja ofrecida
+func sendWaitlistEmail(entry models.WaitlistEntry, hold models.SlotHold) {
+	claimURL := config.Env.FrontendURL + "/waitlist/claim?token=" + url.QueryEscape(hold.Token) + "&lang=" + string(entry.Locale.OrDefault())
+	if err := emailService.SendWaitlistSlotAvailable(&entry, &hold, claimURL); err != nil {
+		fmt.Println("Error sending waitlist email:", err)
+	}
+}
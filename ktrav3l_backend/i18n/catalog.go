@@ -0,0 +1,45 @@
+package i18n
+
+import "fmt"
+
+// messages es el catálogo de textos de los emails y calendarios, por idioma y clave.
+// Las claves que falten en un idioma se toman de Default.
+var messages = map[Locale]map[string]string{
+	Spanish: messagesES,
+	English: messagesEN,
+	Italian: messagesIT,
+}
+
+// apiErrors traduce los mensajes de error de la API. La clave es el mensaje original
+// que usan los controladores (casi todos en inglés); sin traducción se usa tal cual.
+var apiErrors = map[Locale]map[string]string{
+	Spanish: apiErrorsES,
+	English: apiErrorsEN,
+	Italian: apiErrorsIT,
+}
+
+// T devuelve el texto de key en el idioma pedido, con args aplicados como en fmt.Sprintf
+func T(locale Locale, key string, args ...interface{}) string {
+	message, ok := messages[locale.OrDefault()][key]
+	if !ok {
+		message, ok = messages[Default][key]
+	}
+	if !ok {
+		message = key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Error traduce un mensaje de error de la API, con args aplicados como en fmt.Sprintf
+func Error(locale Locale, message string, args ...interface{}) string {
+	if translated, ok := apiErrors[locale.OrDefault()][message]; ok {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
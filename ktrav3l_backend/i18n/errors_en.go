@@ -0,0 +1,12 @@
+package i18n
+
+// apiErrorsEN solo traduce los mensajes que los controladores escriben en español
+var apiErrorsEN = map[string]string{
+	"Cuenta no encontrada":               "Account not found",
+	"El teléfono debe tener 10 dígitos":  "Phone number must have 10 digits",
+	"Error al actualizar cuenta":         "Error updating account",
+	"Error al crear cuenta bancaria":     "Error creating bank account",
+	"Error al desactivar cuenta":         "Error deactivating account",
+	"Error al obtener cuentas bancarias": "Error fetching bank accounts",
+	"ID inválido":                        "Invalid ID",
+}
@@ -0,0 +1,115 @@
+package i18n
+
+var apiErrorsES = map[string]string{
+	"Advisor not available at this time":                                  "El asesor no está disponible en ese horario",
+	"Advisor not found":                                                   "Asesor no encontrado",
+	"Appointment must end on the same day":                                "La cita debe terminar el mismo día",
+	"Appointment not found":                                               "Cita no encontrada",
+	"Appointment type not available":                                      "Tipo de cita no disponible",
+	"Appointment type not found":                                          "Tipo de cita no encontrado",
+	"Appointments can only be changed up to %d minutes before they start": "Las citas solo se pueden cambiar hasta %d minutos antes de su inicio",
+	"Calendar feed not found":                                             "Calendario no encontrado",
+	"Calendar file is required":                                           "El archivo de calendario es obligatorio",
+	"Cannot book a past hour":                                             "No se puede reservar una hora pasada",
+	"Cannot change appointment status from %s to %s":                      "No se puede cambiar el estado de la cita de %s a %s",
+	"Cannot edit completed appointments":                                  "No se pueden editar citas completadas",
+	"Cannot mark as no-show before the appointment starts":                "No se puede marcar como no presentado antes de que empiece la cita",
+	"Claim expired or already used":                                       "La oferta venció o ya fue usada",
+	"Claim not found":                                                     "Oferta no encontrada",
+	"Confirmation not found or already used":                              "Confirmación no encontrada o ya utilizada",
+	"Date range cannot exceed %d days":                                    "El rango de fechas no puede superar %d días",
+	"Date range is in the past":                                           "El rango de fechas ya pasó",
+	"Email not found":                                                     "Email no encontrado",
+	"Error cancelling appointment":                                        "Error al cancelar la cita",
+	"Error checking availability":                                         "Error al consultar la disponibilidad",
+	"Error creating advisor":                                              "Error al crear el asesor",
+	"Error creating appointment":                                          "Error al crear la cita",
+	"Error creating appointment type":                                     "Error al crear el tipo de cita",
+	"Error creating calendar feed":                                        "Error al crear el calendario",
+	"Error creating meeting platform":                                     "Error al crear la plataforma de reunión",
+	"Error creating uploads directory":                                    "Error al crear el directorio de archivos",
+	"Error deactivating advisor":                                          "Error al desactivar el asesor",
+	"Error deleting meeting platform":                                     "Error al eliminar la plataforma de reunión",
+	"Error holding time slot":                                             "Error al reservar temporalmente la franja",
+	"Error joining waitlist":                                              "Error al unirse a la lista de espera",
+	"Error leaving waitlist":                                              "Error al salir de la lista de espera",
+	"Error parsing form data":                                             "Error al leer el formulario",
+	"Error reading calendar file":                                         "Error al leer el archivo de calendario",
+	"Error releasing hold":                                                "Error al liberar la reserva temporal",
+	"Error rescheduling appointment":                                      "Error al reprogramar la cita",
+	"Error retrying email":                                                "Error al reintentar el email",
+	"Error rotating calendar feed":                                        "Error al regenerar el calendario",
+	"Error saving file":                                                   "Error al guardar el archivo",
+	"Error updating advisor":                                              "Error al actualizar el asesor",
+	"Error updating appointment":                                          "Error al actualizar la cita",
+	"Error updating appointment type":                                     "Error al actualizar el tipo de cita",
+	"Error updating meeting platform":                                     "Error al actualizar la plataforma de reunión",
+	"Failed to apply rules":                                               "Error al aplicar las reglas",
+	"Failed to create rule":                                               "Error al crear la regla",
+	"Failed to delete rule":                                               "Error al eliminar la regla",
+	"Failed to import calendar":                                           "Error al importar el calendario",
+	"Failed to update rule":                                               "Error al actualizar la regla",
+	"Hold does not match the requested slot":                              "La reserva temporal no corresponde a la franja pedida",
+	"Hold expired or not found":                                           "La reserva temporal venció o no existe",
+	"Hold not found":                                                      "Reserva temporal no encontrada",
+	"Invalid ID":                                                          "ID inválido",
+	"Invalid advisor ID":                                                  "ID de asesor inválido",
+	"Invalid appointment type ID":                                         "ID de tipo de cita inválido",
+	"Invalid booking window":                                              "Ventana de reserva inválida",
+	"Invalid calendar file: %s":                                           "Archivo de calendario inválido: %s",
+	"Invalid capacity":                                                    "Capacidad inválida",
+	"Invalid data":                                                        "Datos inválidos",
+	"Invalid data: %s":                                                    "Datos inválidos: %s",
+	"Invalid date format":                                                 "Formato de fecha inválido",
+	"Invalid day of week":                                                 "Día de la semana inválido",
+	"Invalid duration":                                                    "Duración inválida",
+	"Invalid endDate":                                                     "endDate inválida",
+	"Invalid file type. Only JPG, PNG, and PDF allowed":                   "Tipo de archivo inválido. Solo se permiten JPG, PNG y PDF",
+	"Invalid from date":                                                   "Fecha from inválida",
+	"Invalid fromDate":                                                    "fromDate inválida",
+	"Invalid hour":                                                        "Hora inválida",
+	"Invalid locale":                                                      "Idioma inválido",
+	"Invalid month format":                                                "Formato de mes inválido",
+	"Invalid platform ID":                                                 "ID de plataforma inválido",
+	"Invalid recurrence kind":                                             "Tipo de recurrencia inválido",
+	"Invalid rule mode":                                                   "Modo de regla inválido",
+	"Invalid startDate":                                                   "startDate inválida",
+	"Invalid time":                                                        "Hora inválida",
+	"Invalid timezone":                                                    "Zona horaria inválida",
+	"Invalid to date":                                                     "Fecha to inválida",
+	"Invalid toDate":                                                      "toDate inválida",
+	"Invalid waitlist entry ID":                                           "ID de lista de espera inválido",
+	"Invalid weekday":                                                     "Día de la semana inválido",
+	"Meeting platform ID is required":                                     "La plataforma de reunión es obligatoria",
+	"Meeting platform not found":                                          "Plataforma de reunión no encontrada",
+	"Missing required fields":                                             "Faltan campos obligatorios",
+	"Name is required":                                                    "El nombre es obligatorio",
+	"Only failed emails can be retried":                                   "Solo se pueden reintentar emails fallidos",
+	"Only pending or approved appointments can be added to a calendar": "Solo se pueden agregar al calendario citas pendientes o aprobadas",
+	"Only pending or approved appointments can be changed":             "Solo se pueden cambiar citas pendientes o aprobadas",
+	"Only pending or approved appointments can be moved":               "Solo se pueden mover citas pendientes o aprobadas",
+	"Open mode is only allowed for specific-date rules":                "El modo open solo se permite en reglas de fecha específica",
+	"Range cannot exceed %d days":                                      "El rango no puede superar %d días",
+	"Range cannot exceed 366 days":                                     "El rango no puede superar 366 días",
+	"Receipt file is required":                                         "El comprobante es obligatorio",
+	"Receipt file not found":                                           "Comprobante no encontrado",
+	"Recurrence has too many occurrences: %s":                          "La recurrencia tiene demasiadas repeticiones: %s",
+	"Recurrence needs COUNT or UNTIL unless it is yearly: %s":          "La recurrencia necesita COUNT o UNTIL si no es anual: %s",
+	"Rejection reason is required":                                     "La razón de rechazo es obligatoria",
+	"Rule not found":                                                   "Regla no encontrada",
+	"Selected time is outside the booking window":                      "El horario elegido está fuera de la ventana de reserva",
+	"This day is blocked":                                              "Este día está bloqueado",
+	"This email is already on the waitlist for this appointment type":  "Este email ya está en la lista de espera de este tipo de cita",
+	"This time slot is blocked":                                        "Esta franja está bloqueada",
+	"Time slot not available":                                          "Franja no disponible",
+	"Too many active holds, release one or wait for it to expire":      "Demasiadas reservas temporales activas, libera una o espera a que venza",
+	"Too many requests, try again later":                               "Demasiadas solicitudes, intenta más tarde",
+	"Unsupported recurrence rule: %s":                                  "Regla de recurrencia no soportada: %s",
+	"User not found":                                                   "Usuario no encontrado",
+	"Verification required":                                            "Se requiere verificación",
+	"Waitlist entry is no longer active":                               "La entrada de la lista de espera ya no está activa",
+	"Waitlist entry not found":                                         "Entrada de la lista de espera no encontrada",
+	"month and day are required":                                       "month y day son obligatorios",
+	"to must not be before from":                                       "to no puede ser anterior a from",
+	"weekday and weekOfMonth are required":                             "weekday y weekOfMonth son obligatorios",
+}
@@ -0,0 +1,122 @@
+package i18n
+
+var apiErrorsIT = map[string]string{
+	"Advisor not available at this time":                                  "Il consulente non è disponibile in questo orario",
+	"Advisor not found":                                                   "Consulente non trovato",
+	"Appointment must end on the same day":                                "L'appuntamento deve terminare lo stesso giorno",
+	"Appointment not found":                                               "Appuntamento non trovato",
+	"Appointment type not available":                                      "Tipo di appuntamento non disponibile",
+	"Appointment type not found":                                          "Tipo di appuntamento non trovato",
+	"Appointments can only be changed up to %d minutes before they start": "Gli appuntamenti possono essere modificati solo fino a %d minuti prima dell'inizio",
+	"Calendar feed not found":                                             "Calendario non trovato",
+	"Calendar file is required":                                           "Il file del calendario è obbligatorio",
+	"Cannot book a past hour":                                             "Non è possibile prenotare un orario passato",
+	"Cannot change appointment status from %s to %s":                      "Non è possibile cambiare lo stato dell'appuntamento da %s a %s",
+	"Cannot edit completed appointments":                                  "Non è possibile modificare appuntamenti completati",
+	"Cannot mark as no-show before the appointment starts":                "Non si può segnare come non presentato prima che inizi l'appuntamento",
+	"Claim expired or already used":                                       "L'offerta è scaduta o è già stata usata",
+	"Claim not found":                                                     "Offerta non trovata",
+	"Confirmation not found or already used":                              "Conferma non trovata o già utilizzata",
+	"Cuenta no encontrada":                                                "Conto non trovato",
+	"Date range cannot exceed %d days":                                    "L'intervallo di date non può superare %d giorni",
+	"Date range is in the past":                                           "L'intervallo di date è già passato",
+	"El teléfono debe tener 10 dígitos":                                   "Il numero di telefono deve avere 10 cifre",
+	"Email not found":                                                     "Email non trovata",
+	"Error al actualizar cuenta":                                          "Errore durante l'aggiornamento del conto",
+	"Error al crear cuenta bancaria":                                      "Errore durante la creazione del conto bancario",
+	"Error al desactivar cuenta":                                          "Errore durante la disattivazione del conto",
+	"Error al obtener cuentas bancarias":                                  "Errore durante il recupero dei conti bancari",
+	"Error cancelling appointment":                                        "Errore durante l'annullamento dell'appuntamento",
+	"Error checking availability":                                         "Errore durante la verifica della disponibilità",
+	"Error creating advisor":                                              "Errore durante la creazione del consulente",
+	"Error creating appointment":                                          "Errore durante la creazione dell'appuntamento",
+	"Error creating appointment type":                                     "Errore durante la creazione del tipo di appuntamento",
+	"Error creating calendar feed":                                        "Errore durante la creazione del calendario",
+	"Error creating meeting platform":                                     "Errore durante la creazione della piattaforma di riunione",
+	"Error creating uploads directory":                                    "Errore durante la creazione della cartella dei file",
+	"Error deactivating advisor":                                          "Errore durante la disattivazione del consulente",
+	"Error deleting meeting platform":                                     "Errore durante l'eliminazione della piattaforma di riunione",
+	"Error holding time slot":                                             "Errore durante la prenotazione temporanea dell'orario",
+	"Error joining waitlist":                                              "Errore durante l'iscrizione alla lista d'attesa",
+	"Error leaving waitlist":                                              "Errore durante l'uscita dalla lista d'attesa",
+	"Error parsing form data":                                             "Errore durante la lettura del modulo",
+	"Error reading calendar file":                                         "Errore durante la lettura del file del calendario",
+	"Error releasing hold":                                                "Errore durante il rilascio della prenotazione temporanea",
+	"Error rescheduling appointment":                                      "Errore durante la riprogrammazione dell'appuntamento",
+	"Error retrying email":                                                "Errore durante il nuovo invio dell'email",
+	"Error rotating calendar feed":                                        "Errore durante la rigenerazione del calendario",
+	"Error saving file":                                                   "Errore durante il salvataggio del file",
+	"Error updating advisor":                                              "Errore durante l'aggiornamento del consulente",
+	"Error updating appointment":                                          "Errore durante l'aggiornamento dell'appuntamento",
+	"Error updating appointment type":                                     "Errore durante l'aggiornamento del tipo di appuntamento",
+	"Error updating meeting platform":                                     "Errore durante l'aggiornamento della piattaforma di riunione",
+	"Failed to apply rules":                                               "Impossibile applicare le regole",
+	"Failed to create rule":                                               "Impossibile creare la regola",
+	"Failed to delete rule":                                               "Impossibile eliminare la regola",
+	"Failed to import calendar":                                           "Impossibile importare il calendario",
+	"Failed to update rule":                                               "Impossibile aggiornare la regola",
+	"Hold does not match the requested slot":                              "La prenotazione temporanea non corrisponde all'orario richiesto",
+	"Hold expired or not found":                                           "La prenotazione temporanea è scaduta o non esiste",
+	"Hold not found":                                                      "Prenotazione temporanea non trovata",
+	"ID inválido":                                                         "ID non valido",
+	"Invalid ID":                                                          "ID non valido",
+	"Invalid advisor ID":                                                  "ID del consulente non valido",
+	"Invalid appointment type ID":                                         "ID del tipo di appuntamento non valido",
+	"Invalid booking window":                                              "Finestra di prenotazione non valida",
+	"Invalid calendar file: %s":                                           "File del calendario non valido: %s",
+	"Invalid capacity":                                                    "Capacità non valida",
+	"Invalid data":                                                        "Dati non validi",
+	"Invalid data: %s":                                                    "Dati non validi: %s",
+	"Invalid date format":                                                 "Formato della data non valido",
+	"Invalid day of week":                                                 "Giorno della settimana non valido",
+	"Invalid duration":                                                    "Durata non valida",
+	"Invalid endDate":                                                     "endDate non valida",
+	"Invalid file type. Only JPG, PNG, and PDF allowed":                   "Tipo di file non valido. Sono ammessi solo JPG, PNG e PDF",
+	"Invalid from date":                                                   "Data from non valida",
+	"Invalid fromDate":                                                    "fromDate non valida",
+	"Invalid hour":                                                        "Ora non valida",
+	"Invalid locale":                                                      "Lingua non valida",
+	"Invalid month format":                                                "Formato del mese non valido",
+	"Invalid platform ID":                                                 "ID della piattaforma non valido",
+	"Invalid recurrence kind":                                             "Tipo di ricorrenza non valido",
+	"Invalid rule mode":                                                   "Modalità della regola non valida",
+	"Invalid startDate":                                                   "startDate non valida",
+	"Invalid time":                                                        "Ora non valida",
+	"Invalid timezone":                                                    "Fuso orario non valido",
+	"Invalid to date":                                                     "Data to non valida",
+	"Invalid toDate":                                                      "toDate non valida",
+	"Invalid waitlist entry ID":                                           "ID della lista d'attesa non valido",
+	"Invalid weekday":                                                     "Giorno della settimana non valido",
+	"Meeting platform ID is required":                                     "La piattaforma di riunione è obbligatoria",
+	"Meeting platform not found":                                          "Piattaforma di riunione non trovata",
+	"Missing required fields":                                             "Mancano campi obbligatori",
+	"Name is required":                                                    "Il nome è obbligatorio",
+	"Only failed emails can be retried":                                   "Si possono reinviare solo le email non consegnate",
+	"Only pending or approved appointments can be added to a calendar": "Solo gli appuntamenti in attesa o approvati possono essere aggiunti al calendario",
+	"Only pending or approved appointments can be changed":             "Solo gli appuntamenti in attesa o approvati possono essere modificati",
+	"Only pending or approved appointments can be moved":               "Solo gli appuntamenti in attesa o approvati possono essere spostati",
+	"Open mode is only allowed for specific-date rules":                "La modalità open è consentita solo per le regole a data specifica",
+	"Range cannot exceed %d days":                                      "L'intervallo non può superare %d giorni",
+	"Range cannot exceed 366 days":                                     "L'intervallo non può superare 366 giorni",
+	"Receipt file is required":                                         "La ricevuta è obbligatoria",
+	"Receipt file not found":                                           "Ricevuta non trovata",
+	"Recurrence has too many occurrences: %s":                          "La ricorrenza ha troppe ripetizioni: %s",
+	"Recurrence needs COUNT or UNTIL unless it is yearly: %s":          "La ricorrenza richiede COUNT o UNTIL se non è annuale: %s",
+	"Rejection reason is required":                                     "Il motivo del rifiuto è obbligatorio",
+	"Rule not found":                                                   "Regola non trovata",
+	"Selected time is outside the booking window":                      "L'orario scelto è fuori dalla finestra di prenotazione",
+	"This day is blocked":                                              "Questo giorno è bloccato",
+	"This email is already on the waitlist for this appointment type":  "Questa email è già nella lista d'attesa per questo tipo di appuntamento",
+	"This time slot is blocked":                                        "Questo orario è bloccato",
+	"Time slot not available":                                          "Orario non disponibile",
+	"Too many active holds, release one or wait for it to expire":      "Troppe prenotazioni temporanee attive, liberane una o attendi che scada",
+	"Too many requests, try again later":                               "Troppe richieste, riprova più tardi",
+	"Unsupported recurrence rule: %s":                                  "Regola di ricorrenza non supportata: %s",
+	"User not found":                                                   "Utente non trovato",
+	"Verification required":                                            "Verifica richiesta",
+	"Waitlist entry is no longer active":                               "L'iscrizione alla lista d'attesa non è più attiva",
+	"Waitlist entry not found":                                         "Iscrizione alla lista d'attesa non trovata",
+	"month and day are required":                                       "month e day sono obbligatori",
+	"to must not be before from":                                       "to non può essere precedente a from",
+	"weekday and weekOfMonth are required":                             "weekday e weekOfMonth sono obbligatori",
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdays = map[Locale][7]string{
+	Spanish: {"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	English: {"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	Italian: {"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+}
+
+var months = map[Locale][12]string{
+	Spanish: {"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	English: {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	Italian: {"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+}
+
+// FormatDate escribe una fecha completa con el día de la semana:
+// "Lunes, 5 de mayo de 2025", "Monday, May 5, 2025" o "Lunedì 5 maggio 2025"
+func FormatDate(locale Locale, t time.Time) string {
+	locale = locale.OrDefault()
+	weekday := capitalize(weekdays[locale][t.Weekday()])
+	month := months[locale][t.Month()-1]
+
+	switch locale {
+	case English:
+		return fmt.Sprintf("%s, %s %d, %d", weekday, month, t.Day(), t.Year())
+	case Italian:
+		return fmt.Sprintf("%s %d %s %d", weekday, t.Day(), month, t.Year())
+	default:
+		return fmt.Sprintf("%s, %d de %s de %d", weekday, t.Day(), month, t.Year())
+	}
+}
+
+// FormatTime escribe una hora dada en minutos desde medianoche: "14:30" en español e
+// italiano y "2:30 PM" en inglés
+func FormatTime(locale Locale, minutes int) string {
+	hour, minute := minutes/60, minutes%60
+	if locale.OrDefault() != English {
+		return fmt.Sprintf("%02d:%02d", hour, minute)
+	}
+	suffix := "AM"
+	if hour >= 12 {
+		suffix = "PM"
+	}
+	if hour%12 == 0 {
+		return fmt.Sprintf("12:%02d %s", minute, suffix)
+	}
+	return fmt.Sprintf("%d:%02d %s", hour%12, minute, suffix)
+}
+
+// FormatClock escribe la hora de un instante como FormatTime
+func FormatClock(locale Locale, t time.Time) string {
+	return FormatTime(locale, t.Hour()*60+t.Minute())
+}
+
+// FormatDuration expresa una duración en horas si es exacta o en minutos: "24 horas"
+func FormatDuration(locale Locale, minutes int) string {
+	if minutes%60 == 0 {
+		if minutes == 60 {
+			return T(locale, "duration.hour")
+		}
+		return T(locale, "duration.hours", minutes/60)
+	}
+	return T(locale, "duration.minutes", minutes)
+}
+
+func capitalize(value string) string {
+	for i := range value {
+		if i > 0 {
+			return strings.ToUpper(value[:i]) + value[i:]
+		}
+	}
+	return strings.ToUpper(value)
+}
@@ -0,0 +1,92 @@
+// Package i18n reúne los idiomas soportados, los catálogos de mensajes de los emails y
+// de los errores de la API, y el formato de fechas y horas de cada idioma.
+package i18n
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale es el código ISO 639-1 de un idioma soportado
+type Locale string
+
+const (
+	Spanish Locale = "es"
+	English Locale = "en"
+	Italian Locale = "it"
+)
+
+// Default es el idioma del negocio: el de los avisos al admin y el de las citas que no
+// tienen idioma guardado
+const Default = Spanish
+
+// Supported son los idiomas con catálogo completo
+var Supported = []Locale{Spanish, English, Italian}
+
+// Parse reconoce un idioma soportado en valores como "it", "it-IT" o "IT_it"
+func Parse(value string) (Locale, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, "-_"); i >= 0 {
+		value = value[:i]
+	}
+	for _, locale := range Supported {
+		if Locale(value) == locale {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// OrDefault devuelve el idioma si es soportado o Default si no (p. ej. citas antiguas)
+func (l Locale) OrDefault() Locale {
+	if locale, ok := Parse(string(l)); ok {
+		return locale
+	}
+	return Default
+}
+
+// Negotiate elige el idioma soportado con mayor preferencia de un header Accept-Language
+func Negotiate(acceptLanguage string) (Locale, bool) {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		locale, ok := Parse(fields[0])
+		if !ok {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			if value, found := strings.CutPrefix(strings.TrimSpace(param), "q="); found {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{locale, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale, true
+}
+
+// FromRequest toma el idioma del parámetro ?lang= o del header Accept-Language, o
+// devuelve fallback si el cliente no pidió ninguno soportado
+func FromRequest(r *http.Request, fallback Locale) Locale {
+	if locale, ok := Parse(r.URL.Query().Get("lang")); ok {
+		return locale
+	}
+	if locale, ok := Negotiate(r.Header.Get("Accept-Language")); ok {
+		return locale
+	}
+	return fallback
+}
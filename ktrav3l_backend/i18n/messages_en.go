@@ -0,0 +1,109 @@
+package i18n
+
+var messagesEN = map[string]string{
+	"language.es": "Spanish",
+	"language.en": "English",
+	"language.it": "Italian",
+
+	"duration.hour":    "1 hour",
+	"duration.hours":   "%d hours",
+	"duration.minutes": "%d minutes",
+
+	"email.greeting":          "Hello",
+	"email.dateAtTime":        "%s at %s",
+	"email.important":         "Important:",
+	"email.questions":         "If you have any questions, feel free to contact us.",
+	"email.adminNote":         "Note from our team:",
+	"email.field.code":        "Booking code:",
+	"email.field.type":        "Appointment type:",
+	"email.field.date":        "Date:",
+	"email.field.time":        "Time:",
+	"email.field.dateTime":    "Date and time:",
+	"email.field.email":       "Email:",
+	"email.field.phone":       "Phone:",
+	"email.field.status":      "Status:",
+	"email.field.client":      "Client:",
+	"email.field.language":    "Language:",
+	"email.field.meetingLink": "Meeting link:",
+	"email.status.pending":    "Pending",
+	"email.status.approved":   "Approved",
+	"email.status.rejected":   "Rejected",
+	"email.status.cancelled":  "Cancelled",
+
+	"email.confirmation.subject":       "Booking confirmation - %s",
+	"email.confirmation.title":         "Booking Received",
+	"email.confirmation.intro":         "Your booking has been received successfully. Here are the details:",
+	"email.confirmation.status":        "Awaiting confirmation",
+	"email.confirmation.nextTitle":     "What's next?",
+	"email.confirmation.nextStatus":    "You can check the status of your booking at any time by entering your code at:",
+	"email.confirmation.nextNotify":    "You will receive an email as soon as your booking is approved.",
+	"email.confirmation.manageTitle":   "Need to cancel or change the date?",
+	"email.confirmation.manageText":    "You can do so up to %s before the appointment at:",
+	"email.confirmation.manageWarning": "Do not share this link: it allows changes to your booking.",
+
+	"email.approved.subject":       "Your booking has been approved! - %s",
+	"email.approved.title":         "Booking Approved!",
+	"email.approved.intro":         "Great news! Your booking has been",
+	"email.approved.introStatus":   "approved",
+	"email.approved.contactPhone":  "Contact phone:",
+	"email.approved.reminderTitle": "Reminder:",
+	"email.approved.reminder":      "Please make sure you are available on the date and time shown. If you have any questions, feel free to contact us.",
+
+	"email.rejected.subject":       "About your booking - %s",
+	"email.rejected.title":         "About your booking",
+	"email.rejected.intro":         "We are sorry to let you know that your booking could not be processed.",
+	"email.rejected.requestedDate": "Requested date:",
+	"email.rejected.reason":        "Reason:",
+	"email.rejected.ruleConflict":  "The time of your appointment is no longer available",
+	"email.rejected.nextTitle":     "What can you do?",
+	"email.rejected.nextBook":      "If you would like to make a new booking, you can do so at:",
+
+	"email.moved.subject":     "Your appointment has been moved - %s",
+	"email.moved.title":       "Appointment Moved",
+	"email.moved.introBefore": "We'd like to let you know that your appointment",
+	"email.moved.introAfter":  "has been moved to a new date and time.",
+	"email.moved.oldDate":     "Previous date and time:",
+	"email.moved.newDate":     "New date and time:",
+	"email.moved.important":   "Please take note of the new date and time. If you have any questions or need more information, feel free to contact us.",
+
+	"email.cancelled.subject":       "Your appointment has been cancelled - %s",
+	"email.cancelled.title":         "Appointment Cancelled",
+	"email.cancelled.intro":         "This confirms that you cancelled your appointment. The time slot is now free for other clients.",
+	"email.cancelled.nextBook":      "If you would like to make a new booking, you can do so at:",
+	"email.cancelled.businessIntro": "We are sorry to let you know that we had to cancel your appointment. The time slot is no longer available.",
+
+	"email.waitlist.subject":    "A time slot opened up for your appointment",
+	"email.waitlist.title":      "A time slot is available!",
+	"email.waitlist.intro":      "A time slot opened up on the dates you were waitlisted for. We are holding it for you for a limited time.",
+	"email.waitlist.claimTitle": "Book your appointment:",
+	"email.waitlist.important":  "The slot is held for you until %s. After that it will be offered to the next person on the waitlist.",
+
+	"email.waitlistConfirm.subject":     "Confirm your spot on the waitlist",
+	"email.waitlistConfirm.title":       "Confirm your Email",
+	"email.waitlistConfirm.intro":       "We received your waitlist request. Confirm your email so we can let you know when a time slot opens up.",
+	"email.waitlistConfirm.dates":       "Dates:",
+	"email.waitlistConfirm.range":       "From %s to %s",
+	"email.waitlistConfirm.actionTitle": "Confirm your spot:",
+	"email.waitlistConfirm.ignore":      "If you did not join the waitlist, you can ignore this email.",
+
+	"email.newAppointment.subject":     "New booking received - %s",
+	"email.newAppointment.title":       "New Booking Received",
+	"email.newAppointment.intro":       "A new booking request has been received. Here are the details:",
+	"email.newAppointment.actionTitle": "Action required:",
+	"email.newAppointment.action":      "Go to the admin panel to review and approve or reject this booking:",
+
+	"email.clientChange.subject":           "Booking %s changed - %s",
+	"email.clientChange.title":             "Booking Changed",
+	"email.clientChange.intro":             "The client changed their booking from the management link.",
+	"email.clientChange.panel":             "You can see the booking in the admin panel:",
+	"email.clientChange.cancelled":         "Cancelled",
+	"email.clientChange.cancelledDetail":   "The client cancelled the appointment.",
+	"email.clientChange.reason":            "Reason: %s",
+	"email.clientChange.rescheduled":       "Rescheduled",
+	"email.clientChange.rescheduledDetail": "The client moved the appointment from %s at %s.",
+
+	"calendar.code":        "Booking code: %s",
+	"calendar.platform":    "Platform: %s",
+	"calendar.meetingLink": "Meeting link: %s",
+	"calendar.note":        "Note: %s",
+}
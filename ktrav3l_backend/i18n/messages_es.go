@@ -0,0 +1,109 @@
+package i18n
+
+var messagesES = map[string]string{
+	"language.es": "Español",
+	"language.en": "Inglés",
+	"language.it": "Italiano",
+
+	"duration.hour":    "1 hora",
+	"duration.hours":   "%d horas",
+	"duration.minutes": "%d minutos",
+
+	"email.greeting":          "Hola",
+	"email.dateAtTime":        "%s a las %s",
+	"email.important":         "Importante:",
+	"email.questions":         "Si tienes alguna pregunta, no dudes en contactarnos.",
+	"email.adminNote":         "Nota del administrador:",
+	"email.field.code":        "Código de reserva:",
+	"email.field.type":        "Tipo de cita:",
+	"email.field.date":        "Fecha:",
+	"email.field.time":        "Hora:",
+	"email.field.dateTime":    "Fecha y hora:",
+	"email.field.email":       "Email:",
+	"email.field.phone":       "Teléfono:",
+	"email.field.status":      "Estado:",
+	"email.field.client":      "Cliente:",
+	"email.field.language":    "Idioma:",
+	"email.field.meetingLink": "Enlace de reunión:",
+	"email.status.pending":    "Pendiente",
+	"email.status.approved":   "Aprobada",
+	"email.status.rejected":   "Rechazada",
+	"email.status.cancelled":  "Cancelada",
+
+	"email.confirmation.subject":       "Confirmación de reserva - %s",
+	"email.confirmation.title":         "Reserva Confirmada",
+	"email.confirmation.intro":         "Tu reserva ha sido recibida exitosamente. A continuación los detalles:",
+	"email.confirmation.status":        "Pendiente de confirmación",
+	"email.confirmation.nextTitle":     "¿Qué sigue?",
+	"email.confirmation.nextStatus":    "Puedes consultar el estado de tu reserva en cualquier momento ingresando tu código en:",
+	"email.confirmation.nextNotify":    "Recibirás una notificación por email una vez que tu reserva sea aprobada.",
+	"email.confirmation.manageTitle":   "¿Necesitas cancelar o cambiar la fecha?",
+	"email.confirmation.manageText":    "Puedes hacerlo hasta %s antes de la cita en:",
+	"email.confirmation.manageWarning": "No compartas este enlace: permite modificar tu reserva.",
+
+	"email.approved.subject":       "¡Tu reserva ha sido aprobada! - %s",
+	"email.approved.title":         "¡Reserva Aprobada!",
+	"email.approved.intro":         "¡Excelentes noticias! Tu reserva ha sido",
+	"email.approved.introStatus":   "aprobada",
+	"email.approved.contactPhone":  "Teléfono de contacto:",
+	"email.approved.reminderTitle": "Recordatorio:",
+	"email.approved.reminder":      "Por favor asegúrate de estar disponible en la fecha y hora indicadas. Si tienes alguna pregunta, no dudes en contactarnos.",
+
+	"email.rejected.subject":       "Información sobre tu reserva - %s",
+	"email.rejected.title":         "Información sobre tu reserva",
+	"email.rejected.intro":         "Lamentamos informarte que tu reserva no pudo ser procesada.",
+	"email.rejected.requestedDate": "Fecha solicitada:",
+	"email.rejected.reason":        "Razón:",
+	"email.rejected.ruleConflict":  "El horario de tu cita ya no está disponible",
+	"email.rejected.nextTitle":     "¿Qué puedes hacer?",
+	"email.rejected.nextBook":      "Si deseas hacer una nueva reserva, puedes hacerlo en:",
+
+	"email.moved.subject":     "Tu cita ha sido movida - %s",
+	"email.moved.title":       "Cita Movida",
+	"email.moved.introBefore": "Te informamos que tu cita",
+	"email.moved.introAfter":  "ha sido movida a una nueva fecha y hora.",
+	"email.moved.oldDate":     "Fecha y hora anterior:",
+	"email.moved.newDate":     "Nueva fecha y hora:",
+	"email.moved.important":   "Por favor ten en cuenta la nueva fecha y hora. Si tienes alguna pregunta o necesitas más información, no dudes en contactarnos.",
+
+	"email.cancelled.subject":       "Tu cita ha sido cancelada - %s",
+	"email.cancelled.title":         "Cita Cancelada",
+	"email.cancelled.intro":         "Confirmamos que cancelaste tu cita. El horario quedó libre para otros clientes.",
+	"email.cancelled.nextBook":      "Si deseas hacer una nueva reserva, puedes hacerlo en:",
+	"email.cancelled.businessIntro": "Lamentamos informarte que tuvimos que cancelar tu cita. El horario ya no está disponible.",
+
+	"email.waitlist.subject":    "Se liberó un horario para tu cita",
+	"email.waitlist.title":      "¡Hay un horario disponible!",
+	"email.waitlist.intro":      "Se liberó un horario en las fechas en que estabas en lista de espera. Lo reservamos para ti por tiempo limitado.",
+	"email.waitlist.claimTitle": "Reserva tu cita:",
+	"email.waitlist.important":  "El horario queda reservado para ti hasta las %s. Después de esa hora se ofrecerá a la siguiente persona en la lista de espera.",
+
+	"email.waitlistConfirm.subject":     "Confirma tu lugar en la lista de espera",
+	"email.waitlistConfirm.title":       "Confirma tu Email",
+	"email.waitlistConfirm.intro":       "Recibimos tu solicitud para la lista de espera. Confirma tu email para que podamos avisarte cuando se libere un horario.",
+	"email.waitlistConfirm.dates":       "Fechas:",
+	"email.waitlistConfirm.range":       "Del %s al %s",
+	"email.waitlistConfirm.actionTitle": "Confirma tu lugar:",
+	"email.waitlistConfirm.ignore":      "Si no te anotaste en la lista de espera, ignora este email.",
+
+	"email.newAppointment.subject":     "Nueva reserva recibida - %s",
+	"email.newAppointment.title":       "Nueva Reserva Recibida",
+	"email.newAppointment.intro":       "Se ha recibido una nueva solicitud de reserva. A continuación los detalles:",
+	"email.newAppointment.actionTitle": "Acción requerida:",
+	"email.newAppointment.action":      "Ingresa al panel de administración para revisar y aprobar o rechazar esta reserva:",
+
+	"email.clientChange.subject":           "Cambio en reserva %s - %s",
+	"email.clientChange.title":             "Cambio en una Reserva",
+	"email.clientChange.intro":             "El cliente modificó su reserva desde el enlace de gestión.",
+	"email.clientChange.panel":             "Puedes ver la reserva en el panel de administración:",
+	"email.clientChange.cancelled":         "Cancelada",
+	"email.clientChange.cancelledDetail":   "El cliente canceló la cita.",
+	"email.clientChange.reason":            "Motivo: %s",
+	"email.clientChange.rescheduled":       "Reprogramada",
+	"email.clientChange.rescheduledDetail": "El cliente movió la cita desde el %s a las %s.",
+
+	"calendar.code":        "Código de reserva: %s",
+	"calendar.platform":    "Plataforma: %s",
+	"calendar.meetingLink": "Enlace de la reunión: %s",
+	"calendar.note":        "Nota: %s",
+}
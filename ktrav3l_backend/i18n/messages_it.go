@@ -0,0 +1,109 @@
+package i18n
+
+var messagesIT = map[string]string{
+	"language.es": "Spagnolo",
+	"language.en": "Inglese",
+	"language.it": "Italiano",
+
+	"duration.hour":    "1 ora",
+	"duration.hours":   "%d ore",
+	"duration.minutes": "%d minuti",
+
+	"email.greeting":          "Ciao",
+	"email.dateAtTime":        "%s alle %s",
+	"email.important":         "Importante:",
+	"email.questions":         "Per qualsiasi domanda, non esitare a contattarci.",
+	"email.adminNote":         "Nota del nostro team:",
+	"email.field.code":        "Codice di prenotazione:",
+	"email.field.type":        "Tipo di appuntamento:",
+	"email.field.date":        "Data:",
+	"email.field.time":        "Ora:",
+	"email.field.dateTime":    "Data e ora:",
+	"email.field.email":       "Email:",
+	"email.field.phone":       "Telefono:",
+	"email.field.status":      "Stato:",
+	"email.field.client":      "Cliente:",
+	"email.field.language":    "Lingua:",
+	"email.field.meetingLink": "Link della riunione:",
+	"email.status.pending":    "In attesa",
+	"email.status.approved":   "Approvata",
+	"email.status.rejected":   "Rifiutata",
+	"email.status.cancelled":  "Annullata",
+
+	"email.confirmation.subject":       "Conferma di prenotazione - %s",
+	"email.confirmation.title":         "Prenotazione Ricevuta",
+	"email.confirmation.intro":         "La tua prenotazione è stata ricevuta correttamente. Ecco i dettagli:",
+	"email.confirmation.status":        "In attesa di conferma",
+	"email.confirmation.nextTitle":     "E adesso?",
+	"email.confirmation.nextStatus":    "Puoi controllare lo stato della tua prenotazione in qualsiasi momento inserendo il tuo codice su:",
+	"email.confirmation.nextNotify":    "Riceverai un'email non appena la tua prenotazione sarà approvata.",
+	"email.confirmation.manageTitle":   "Devi annullare o cambiare la data?",
+	"email.confirmation.manageText":    "Puoi farlo fino a %s prima dell'appuntamento su:",
+	"email.confirmation.manageWarning": "Non condividere questo link: permette di modificare la tua prenotazione.",
+
+	"email.approved.subject":       "La tua prenotazione è stata approvata! - %s",
+	"email.approved.title":         "Prenotazione Approvata!",
+	"email.approved.intro":         "Ottime notizie! La tua prenotazione è stata",
+	"email.approved.introStatus":   "approvata",
+	"email.approved.contactPhone":  "Telefono di contatto:",
+	"email.approved.reminderTitle": "Promemoria:",
+	"email.approved.reminder":      "Assicurati di essere disponibile nella data e nell'ora indicate. Per qualsiasi domanda, non esitare a contattarci.",
+
+	"email.rejected.subject":       "Informazioni sulla tua prenotazione - %s",
+	"email.rejected.title":         "Informazioni sulla tua prenotazione",
+	"email.rejected.intro":         "Siamo spiacenti di informarti che la tua prenotazione non è stata accettata.",
+	"email.rejected.requestedDate": "Data richiesta:",
+	"email.rejected.reason":        "Motivo:",
+	"email.rejected.ruleConflict":  "L'orario del tuo appuntamento non è più disponibile",
+	"email.rejected.nextTitle":     "Cosa puoi fare?",
+	"email.rejected.nextBook":      "Se desideri effettuare una nuova prenotazione, puoi farlo su:",
+
+	"email.moved.subject":     "Il tuo appuntamento è stato spostato - %s",
+	"email.moved.title":       "Appuntamento Spostato",
+	"email.moved.introBefore": "Ti informiamo che il tuo appuntamento",
+	"email.moved.introAfter":  "è stato spostato a una nuova data e ora.",
+	"email.moved.oldDate":     "Data e ora precedenti:",
+	"email.moved.newDate":     "Nuova data e ora:",
+	"email.moved.important":   "Prendi nota della nuova data e ora. Per qualsiasi domanda o ulteriore informazione, non esitare a contattarci.",
+
+	"email.cancelled.subject":       "Il tuo appuntamento è stato annullato - %s",
+	"email.cancelled.title":         "Appuntamento Annullato",
+	"email.cancelled.intro":         "Confermiamo che hai annullato il tuo appuntamento. L'orario è ora disponibile per altri clienti.",
+	"email.cancelled.nextBook":      "Se desideri effettuare una nuova prenotazione, puoi farlo su:",
+	"email.cancelled.businessIntro": "Siamo spiacenti di informarti che abbiamo dovuto annullare il tuo appuntamento. L'orario non è più disponibile.",
+
+	"email.waitlist.subject":    "Si è liberato un orario per il tuo appuntamento",
+	"email.waitlist.title":      "C'è un orario disponibile!",
+	"email.waitlist.intro":      "Si è liberato un orario nelle date per cui eri in lista d'attesa. Lo teniamo riservato per te per un tempo limitato.",
+	"email.waitlist.claimTitle": "Prenota il tuo appuntamento:",
+	"email.waitlist.important":  "L'orario resta riservato per te fino alle %s. Dopo verrà offerto alla persona successiva in lista d'attesa.",
+
+	"email.waitlistConfirm.subject":     "Conferma il tuo posto in lista d'attesa",
+	"email.waitlistConfirm.title":       "Conferma la tua Email",
+	"email.waitlistConfirm.intro":       "Abbiamo ricevuto la tua richiesta per la lista d'attesa. Conferma la tua email per poterti avvisare quando si libera un orario.",
+	"email.waitlistConfirm.dates":       "Date:",
+	"email.waitlistConfirm.range":       "Dal %s al %s",
+	"email.waitlistConfirm.actionTitle": "Conferma il tuo posto:",
+	"email.waitlistConfirm.ignore":      "Se non ti sei iscritto alla lista d'attesa, ignora questa email.",
+
+	"email.newAppointment.subject":     "Nuova prenotazione ricevuta - %s",
+	"email.newAppointment.title":       "Nuova Prenotazione Ricevuta",
+	"email.newAppointment.intro":       "È stata ricevuta una nuova richiesta di prenotazione. Ecco i dettagli:",
+	"email.newAppointment.actionTitle": "Azione richiesta:",
+	"email.newAppointment.action":      "Accedi al pannello di amministrazione per esaminare e approvare o rifiutare questa prenotazione:",
+
+	"email.clientChange.subject":           "Modifica alla prenotazione %s - %s",
+	"email.clientChange.title":             "Modifica a una Prenotazione",
+	"email.clientChange.intro":             "Il cliente ha modificato la prenotazione dal link di gestione.",
+	"email.clientChange.panel":             "Puoi vedere la prenotazione nel pannello di amministrazione:",
+	"email.clientChange.cancelled":         "Annullata",
+	"email.clientChange.cancelledDetail":   "Il cliente ha annullato l'appuntamento.",
+	"email.clientChange.reason":            "Motivo: %s",
+	"email.clientChange.rescheduled":       "Riprogrammata",
+	"email.clientChange.rescheduledDetail": "Il cliente ha spostato l'appuntamento da %s alle %s.",
+
+	"calendar.code":        "Codice di prenotazione: %s",
+	"calendar.platform":    "Piattaforma: %s",
+	"calendar.meetingLink": "Link della riunione: %s",
+	"calendar.note":        "Nota: %s",
+}
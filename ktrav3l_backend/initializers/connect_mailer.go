@@ -0,0 +1,26 @@
+package initializers
+
+import (
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/services/mailer"
+)
+
+// Mailer entrega los emails de la outbox según MAIL_TRANSPORT
+var Mailer mailer.Mailer
+
+func ConnectMailer() {
+	switch config.Env.MailTransport {
+	case "smtp":
+		security := mailer.Security(config.Env.SMTPSecurity)
+		if security != mailer.SecurityStartTLS && security != mailer.SecurityTLS && security != mailer.SecurityNone {
+			panic("Invalid SMTP_SECURITY: " + config.Env.SMTPSecurity)
+		}
+		Mailer = mailer.NewSMTP(config.Env.SMTPHost, config.Env.SMTPPort, config.Env.SMTPUser, config.Env.SMTPPassword, security)
+	case "file":
+		Mailer = mailer.NewFile(config.Env.MailDir)
+	case "memory":
+		Mailer = mailer.NewMemory()
+	default:
+		panic("Invalid MAIL_TRANSPORT: " + config.Env.MailTransport)
+	}
+}
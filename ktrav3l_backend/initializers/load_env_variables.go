@@ -4,6 +4,8 @@ import (
 	"os"
 	"pixelbrew-llc/ktrav3l_backend/config"
 	"pixelbrew-llc/ktrav3l_backend/utils"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -26,12 +28,89 @@ func LoadEnvVariables() {
 		DBName:       utils.MustGetEnv("DB_NAME"),
 		JWTSecret:    utils.MustGetEnv("JWT_SECRET"),
 		UploadsPath:  utils.MustGetEnv("UPLOADS_PATH"),
-		SMTPHost:     utils.MustGetEnv("SMTP_HOST"),
-		SMTPPort:     utils.MustGetEnv("SMTP_PORT"),
-		SMTPUser:     utils.MustGetEnv("SMTP_USER"),
-		SMTPPassword: utils.MustGetEnv("SMTP_PASSWORD"),
 		SMTPFrom:     utils.MustGetEnv("SMTP_FROM"),
 		SMTPFromName: utils.MustGetEnv("SMTP_FROM_NAME"),
 		FrontendURL:  utils.MustGetEnv("FRONTEND_URL"),
+
+		SlotIntervalMinutes: utils.GetEnvInt("SLOT_INTERVAL_MINUTES", 60),
+		SlotCapacity:        utils.GetEnvInt("SLOT_CAPACITY", 1),
+		SlotHoldMinutes:     utils.GetEnvInt("SLOT_HOLD_MINUTES", 15),
+		MaxHoldsPerClient:   utils.GetEnvInt("MAX_HOLDS_PER_CLIENT", 2),
+		MinLeadMinutes:      utils.GetEnvInt("MIN_LEAD_MINUTES", 0),
+		MaxHorizonDays:      utils.GetEnvInt("MAX_HORIZON_DAYS", 0),
+
+		WaitlistClaimMinutes:     utils.GetEnvInt("WAITLIST_CLAIM_MINUTES", 120),
+		SelfServiceCutoffMinutes: utils.GetEnvInt("SELF_SERVICE_CUTOFF_MINUTES", 1440),
+		LookupRateLimit:          utils.GetEnvInt("LOOKUP_RATE_LIMIT", 20),
+		HoldRateLimit:            utils.GetEnvInt("HOLD_RATE_LIMIT", 10),
+		WaitlistRateLimit:        utils.GetEnvInt("WAITLIST_RATE_LIMIT", 5),
+		EmailMaxAttempts:         utils.GetEnvInt("EMAIL_MAX_ATTEMPTS", 6),
+	}
+
+	// Un intervalo <= 0 haría que el cálculo de franjas no termine nunca
+	if config.Env.SlotIntervalMinutes <= 0 || config.Env.SlotIntervalMinutes > 1440 {
+		panic("SLOT_INTERVAL_MINUTES must be between 1 and 1440")
+	}
+	if config.Env.SlotCapacity <= 0 {
+		panic("SLOT_CAPACITY must be greater than 0")
+	}
+	// 0 desactiva el límite; un valor negativo es un error de configuración
+	if config.Env.HoldRateLimit < 0 {
+		panic("HOLD_RATE_LIMIT must be 0 or greater")
+	}
+	if config.Env.WaitlistRateLimit < 0 {
+		panic("WAITLIST_RATE_LIMIT must be 0 or greater")
+	}
+
+	loadMailSettings()
+
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			config.Env.TrustedProxies = append(config.Env.TrustedProxies, proxy)
+		}
+	}
+
+	config.Env.BusinessTimezone = os.Getenv("BUSINESS_TIMEZONE")
+	if config.Env.BusinessTimezone == "" {
+		config.Env.BusinessTimezone = "America/Santo_Domingo"
+	}
+	location, err := time.LoadLocation(config.Env.BusinessTimezone)
+	if err != nil {
+		panic("Invalid BUSINESS_TIMEZONE: " + config.Env.BusinessTimezone)
+	}
+	config.Env.BusinessLocation = location
+}
+
+// loadMailSettings lee el transporte de emails. Las credenciales SMTP solo son obligatorias
+// con MAIL_TRANSPORT=smtp, así en desarrollo se puede usar el maildir sin configurarlas.
+func loadMailSettings() {
+	config.Env.MailTransport = os.Getenv("MAIL_TRANSPORT")
+	if config.Env.MailTransport == "" {
+		config.Env.MailTransport = "smtp"
+	}
+	config.Env.MailDir = os.Getenv("MAIL_DIR")
+	if config.Env.MailDir == "" {
+		config.Env.MailDir = "./mail"
+	}
+
+	config.Env.EmailTemplatesDir = os.Getenv("EMAIL_TEMPLATES_DIR")
+	config.Env.EmailReplyTo = os.Getenv("EMAIL_REPLY_TO")
+
+	getSMTPEnv := os.Getenv
+	if config.Env.MailTransport == "smtp" {
+		getSMTPEnv = utils.MustGetEnv
+	}
+	config.Env.SMTPHost = getSMTPEnv("SMTP_HOST")
+	config.Env.SMTPPort = getSMTPEnv("SMTP_PORT")
+	config.Env.SMTPUser = getSMTPEnv("SMTP_USER")
+	config.Env.SMTPPassword = getSMTPEnv("SMTP_PASSWORD")
+
+	// Por defecto STARTTLS, salvo en el puerto 465 que usa TLS implícito
+	config.Env.SMTPSecurity = os.Getenv("SMTP_SECURITY")
+	if config.Env.SMTPSecurity == "" {
+		config.Env.SMTPSecurity = "starttls"
+		if config.Env.SMTPPort == "465" {
+			config.Env.SMTPSecurity = "tls"
+		}
 	}
 }
@@ -5,10 +5,145 @@ import "pixelbrew-llc/ktrav3l_backend/models"
 func SyncDB() {
 	DB.AutoMigrate(
 		&models.User{},
+		&models.Advisor{},
 		&models.AppointmentType{},
 		&models.AvailabilityRule{},
+		&models.RecurringRule{},
 		&models.BankAccount{},
 		&models.MeetingPlatform{},
 		&models.Appointment{},
+		&models.SlotHold{},
+		&models.WaitlistEntry{},
+		&models.AppointmentEvent{},
+		&models.OutboxEmail{},
 	)
+
+	migrateAppointmentHours()
+	createAppointmentOverlapConstraint()
+	createAppointmentCapacityTrigger()
+	backfillManageTokens()
+	dropRecurringRuleAllDayDefault()
+}
+
+// dropRecurringRuleAllDayDefault quita el default true que tenía all_day: con él, GORM
+// omitía un AllDay false al crear la regla y la base guardaba true
+func dropRecurringRuleAllDayDefault() {
+	if err := DB.Exec("ALTER TABLE recurring_rules ALTER COLUMN all_day DROP DEFAULT").Error; err != nil {
+		panic("failed to drop recurring_rules.all_day default: " + err.Error())
+	}
+}
+
+// migrateAppointmentHours convierte las citas guardadas con el esquema anterior
+// (una hora entera en appointment_hour) a minutos de inicio/fin y elimina la columna vieja.
+// Todas las citas antiguas duraban exactamente una hora.
+func migrateAppointmentHours() {
+	if !DB.Migrator().HasColumn(&models.Appointment{}, "appointment_hour") {
+		return
+	}
+
+	err := DB.Exec(`UPDATE appointments
+		SET start_minute = appointment_hour * 60, end_minute = appointment_hour * 60 + 60
+		WHERE end_minute = 0`).Error
+	if err != nil {
+		panic("failed to migrate appointment hours: " + err.Error())
+	}
+
+	if err := DB.Migrator().DropColumn(&models.Appointment{}, "appointment_hour"); err != nil {
+		panic("failed to drop appointment_hour column: " + err.Error())
+	}
+}
+
+// createAppointmentOverlapConstraint impide a nivel de base de datos que un mismo
+// asesor tenga dos citas activas solapadas. Las citas sin asesor las cubre
+// createAppointmentCapacityTrigger.
+// La versión v2 reemplaza a la original para no contar las citas canceladas.
+func createAppointmentOverlapConstraint() {
+	var count int64
+	DB.Raw("SELECT COUNT(*) FROM pg_constraint WHERE conname = ?", "appointments_advisor_no_overlap_v2").Scan(&count)
+	if count > 0 {
+		return
+	}
+
+	if err := DB.Exec("ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_advisor_no_overlap").Error; err != nil {
+		panic("failed to drop old appointment overlap constraint: " + err.Error())
+	}
+
+	if err := DB.Exec("CREATE EXTENSION IF NOT EXISTS btree_gist").Error; err != nil {
+		panic("failed to enable btree_gist: " + err.Error())
+	}
+
+	err := DB.Exec(`ALTER TABLE appointments ADD CONSTRAINT appointments_advisor_no_overlap_v2
+		EXCLUDE USING gist (
+			advisor_id WITH =,
+			appointment_date WITH =,
+			int4range(start_minute, end_minute) WITH &&
+		) WHERE (advisor_id IS NOT NULL AND status NOT IN ('rejected', 'cancelled') AND deleted_at IS NULL)`).Error
+	if err != nil {
+		panic("failed to create appointment overlap constraint: " + err.Error())
+	}
+}
+
+// createAppointmentCapacityTrigger impide a nivel de base de datos que una franja tenga
+// más citas activas simultáneas que el slot_capacity de la cita que se escribe, tengan o
+// no asesor. Cada escritura toma un advisory lock de su fecha, así que dos transacciones
+// concurrentes no pueden ver la franja libre a la vez aunque no pasen por withDayLock.
+// Solo se verifica al crear la cita, al cambiarla de fecha u horario o al reactivarla.
+func createAppointmentCapacityTrigger() {
+	err := DB.Exec(`CREATE OR REPLACE FUNCTION appointments_check_capacity() RETURNS trigger AS $$
+		DECLARE
+			peak int;
+		BEGIN
+			IF NEW.deleted_at IS NOT NULL OR NEW.status IN ('rejected', 'cancelled') THEN
+				RETURN NEW;
+			END IF;
+			IF TG_OP = 'UPDATE' AND OLD.deleted_at IS NULL AND OLD.status NOT IN ('rejected', 'cancelled')
+				AND OLD.appointment_date = NEW.appointment_date
+				AND OLD.start_minute = NEW.start_minute AND OLD.end_minute = NEW.end_minute THEN
+				RETURN NEW;
+			END IF;
+
+			PERFORM pg_advisory_xact_lock(4202, NEW.appointment_date::date - DATE '2000-01-01');
+
+			-- La ocupación solo puede aumentar al inicio de la cita o cuando empieza otra
+			SELECT COALESCE(MAX(counts.n), 0) INTO peak FROM (
+				SELECT (SELECT COUNT(*) FROM appointments a
+					WHERE a.appointment_date = NEW.appointment_date AND a.id <> NEW.id
+						AND a.deleted_at IS NULL AND a.status NOT IN ('rejected', 'cancelled')
+						AND a.start_minute <= points.minute AND a.end_minute > points.minute) AS n
+				FROM (
+					SELECT NEW.start_minute AS minute
+					UNION
+					SELECT start_minute FROM appointments
+					WHERE appointment_date = NEW.appointment_date AND id <> NEW.id
+						AND deleted_at IS NULL AND status NOT IN ('rejected', 'cancelled')
+						AND start_minute > NEW.start_minute AND start_minute < NEW.end_minute
+				) points
+			) counts;
+
+			IF peak >= NEW.slot_capacity THEN
+				RAISE EXCEPTION 'appointment slot is full' USING ERRCODE = 'exclusion_violation';
+			END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql`).Error
+	if err != nil {
+		panic("failed to create appointment capacity function: " + err.Error())
+	}
+
+	if err := DB.Exec("DROP TRIGGER IF EXISTS appointments_capacity ON appointments").Error; err != nil {
+		panic("failed to drop appointment capacity trigger: " + err.Error())
+	}
+	err = DB.Exec(`CREATE TRIGGER appointments_capacity BEFORE INSERT OR UPDATE ON appointments
+		FOR EACH ROW EXECUTE FUNCTION appointments_check_capacity()`).Error
+	if err != nil {
+		panic("failed to create appointment capacity trigger: " + err.Error())
+	}
+}
+
+// backfillManageTokens genera el token de gestión de las citas creadas antes de que existiera
+func backfillManageTokens() {
+	err := DB.Exec("UPDATE appointments SET manage_token = gen_random_uuid()::text WHERE manage_token IS NULL OR manage_token = ''").Error
+	if err != nil {
+		panic("failed to backfill manage tokens: " + err.Error())
+	}
 }
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimit limita cada IP a limit peticiones por window en las rutas que lo usan.
+// Los contadores viven en memoria: alcanza para una sola instancia del servidor.
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	var mu sync.Mutex
+	windows := make(map[string]*rateWindow)
+	lastCleanup := time.Now()
+
+	return func(c *gin.Context) {
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+		ip := c.ClientIP()
+
+		mu.Lock()
+		// Descartar las ventanas vencidas de vez en cuando para no acumular IPs
+		if now.Sub(lastCleanup) > window {
+			for key, w := range windows {
+				if now.Sub(w.start) >= window {
+					delete(windows, key)
+				}
+			}
+			lastCleanup = now
+		}
+
+		w, ok := windows[ip]
+		if !ok || now.Sub(w.start) >= window {
+			w = &rateWindow{start: now}
+			windows[ip] = w
+		}
+		w.count++
+		exceeded := w.count > limit
+		retryAfter := w.start.Add(window).Sub(now)
+		mu.Unlock()
+
+		if exceeded {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": i18n.Error(i18n.FromRequest(c.Request, i18n.English), "Too many requests, try again later"),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
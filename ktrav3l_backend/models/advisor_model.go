@@ -0,0 +1,25 @@
+package models
+
+import (
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Advisor es un asesor que atiende citas. Puede estar vinculado a un usuario
+// del panel para que vea su propio calendario.
+type Advisor struct {
+	gorm.Model
+	ID       uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID   *uuid.UUID `gorm:"type:uuid;uniqueIndex"` // Usuario del panel (opcional)
+	User     *User      `gorm:"foreignKey:UserID"`
+	Name     string     `gorm:"not null"`
+	Email    string
+	IsActive bool `gorm:"default:true"`
+}
+
+func (a *Advisor) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AppointmentEventAction string
+
+const (
+	EventCreated         AppointmentEventAction = "created"
+	EventApproved        AppointmentEventAction = "approved"
+	EventRejected        AppointmentEventAction = "rejected"
+	EventDone            AppointmentEventAction = "done"
+	EventNoShow          AppointmentEventAction = "no_show"
+	EventCancelled       AppointmentEventAction = "cancelled"
+	EventMoved           AppointmentEventAction = "moved"
+	EventEdited          AppointmentEventAction = "edited"
+	EventPlatformChanged AppointmentEventAction = "platform_changed"
+)
+
+type EventActor string
+
+const (
+	ActorAdmin  EventActor = "admin"  // Usuario del panel (UserID/UserEmail)
+	ActorClient EventActor = "client" // El cliente desde la reserva o su enlace de gestión
+	ActorSystem EventActor = "system" // Procesos automáticos
+)
+
+// FieldChange es el valor anterior y nuevo de un campo
+type FieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// FieldChanges guarda en jsonb los campos modificados por un evento
+type FieldChanges map[string]FieldChange
+
+func (f FieldChanges) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+
+func (f *FieldChanges) Scan(value interface{}) error {
+	if value == nil {
+		*f = FieldChanges{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, f)
+}
+
+// AppointmentEvent registra un cambio en una cita: quién lo hizo, el cambio de estado
+// y los campos modificados. Las filas no se editan ni se borran.
+type AppointmentEvent struct {
+	gorm.Model
+	AppointmentID uuid.UUID              `gorm:"type:uuid;not null;index"`
+	Action        AppointmentEventAction `gorm:"type:varchar(30);not null"`
+	FromStatus    AppointmentStatus      `gorm:"type:varchar(20)"`
+	ToStatus      AppointmentStatus      `gorm:"type:varchar(20)"`
+	Actor         EventActor             `gorm:"type:varchar(10);not null"`
+	UserID        *uuid.UUID             `gorm:"type:uuid"`
+	UserEmail     string                 // Copia del email por si el usuario se elimina
+	Changes       FieldChanges           `gorm:"type:jsonb"`
+	Note          string                 // Motivo de rechazo, cancelación, etc.
+}
+
+// DiffAppointments devuelve los campos que cambiaron entre before y after
+func DiffAppointments(before, after Appointment) FieldChanges {
+	changes := FieldChanges{}
+	compare := func(field, from, to string) {
+		if from != to {
+			changes[field] = FieldChange{From: from, To: to}
+		}
+	}
+
+	compare("firstName", before.FirstName, after.FirstName)
+	compare("lastName", before.LastName, after.LastName)
+	compare("email", before.Email, after.Email)
+	compare("phoneNumber", before.PhoneNumber, after.PhoneNumber)
+	compare("appointmentDate", before.AppointmentDate.Time.UTC().Format("2006-01-02"), after.AppointmentDate.Time.UTC().Format("2006-01-02"))
+	compare("startTime", before.StartTime(), after.StartTime())
+	compare("endTime", before.EndTime(), after.EndTime())
+	compare("status", string(before.Status), string(after.Status))
+	compare("rejectionReason", before.RejectionReason, after.RejectionReason)
+	compare("meetingLink", before.MeetingLink, after.MeetingLink)
+	compare("adminNote", before.AdminNote, after.AdminNote)
+	compare("meetingPlatformId", uuidString(before.MeetingPlatformID), uuidString(after.MeetingPlatformID))
+	compare("advisorId", uuidString(before.AdvisorID), uuidString(after.AdvisorID))
+	compare("locale", string(before.Locale), string(after.Locale))
+	return changes
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"time"
+
 	uuid "github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -8,10 +11,12 @@ import (
 type AppointmentStatus string
 
 const (
-	StatusPending  AppointmentStatus = "pending"
-	StatusApproved AppointmentStatus = "approved"
-	StatusRejected AppointmentStatus = "rejected"
-	StatusDone     AppointmentStatus = "done"
+	StatusPending   AppointmentStatus = "pending"
+	StatusApproved  AppointmentStatus = "approved"
+	StatusRejected  AppointmentStatus = "rejected"
+	StatusDone      AppointmentStatus = "done"
+	StatusCancelled AppointmentStatus = "cancelled" // Cancelada por el cliente
+	StatusNoShow    AppointmentStatus = "no_show"   // El cliente no se presentó
 )
 
 type BankType string
@@ -30,13 +35,14 @@ const (
 type Appointment struct {
 	gorm.Model
 	ID                uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ShortID           string            `gorm:"uniqueIndex;size:8"` // UUID corto hasta primer -
+	ShortID           string            `gorm:"uniqueIndex;size:12"` // Código público de consulta (ver lookup_code.go)
 	FirstName         string            `gorm:"not null"`
 	LastName          string            `gorm:"not null"`
 	Email             string            `gorm:"not null"`
 	PhoneNumber       string            `gorm:"not null;size:12"` // ###-###-####
 	AppointmentDate   DateOnly          `gorm:"not null"`
-	AppointmentHour   int               `gorm:"not null"` // Hora en formato 24h (0-23)
+	StartMinute       int               `gorm:"not null;default:0"` // Minuto de inicio desde medianoche (0-1439)
+	EndMinute         int               `gorm:"not null;default:0"` // Minuto de fin desde medianoche (exclusivo)
 	AppointmentTypeID uint              `gorm:"not null"`
 	AppointmentType   AppointmentType   `gorm:"foreignKey:AppointmentTypeID"`
 	BankAccountID     *uuid.UUID        // ID de la cuenta bancaria seleccionada
@@ -50,6 +56,20 @@ type Appointment struct {
 	MeetingPlatformID *uuid.UUID        // ID de la plataforma de reunión
 	MeetingPlatform   *MeetingPlatform  `gorm:"foreignKey:MeetingPlatformID"`
 	CreatedByAdmin    bool              `gorm:"default:false"`
+	AdvisorID         *uuid.UUID        `gorm:"type:uuid;index"` // Asesor asignado (se asigna al aprobar)
+	Advisor           *Advisor          `gorm:"foreignKey:AdvisorID"`
+	// Token secreto que se envía al cliente para cancelar o reprogramar su cita
+	ManageToken string `gorm:"index" json:"-"`
+	// Versión de la invitación de calendario enviada al cliente (SEQUENCE del .ics)
+	CalendarSequence int `gorm:"not null;default:0"`
+	// Idioma de los emails e invitaciones del cliente, elegido al reservar
+	Locale i18n.Locale `gorm:"type:varchar(5);not null;default:'es'"`
+	// Cupo de la franja al reservar o mover la cita; la base de datos rechaza la escritura
+	// si la franja ya tiene esa cantidad de citas activas (ver initializers/sync_db.go)
+	SlotCapacity int `gorm:"not null;default:1" json:"-"`
+	// El admin reservó o movió la cita ignorando las reglas (Override): los cambios de
+	// reglas la informan como conflicto pero no la rechazan ni la mueven
+	BookedWithOverride bool `gorm:"not null;default:false"`
 }
 
 // BeforeCreate hook para generar el ShortID
@@ -57,7 +77,34 @@ func (a *Appointment) BeforeCreate(tx *gorm.DB) error {
 	if a.ID == uuid.Nil {
 		a.ID = uuid.New()
 	}
-	// Generar ShortID: primeros 8 caracteres del UUID (hasta primer -)
-	a.ShortID = a.ID.String()[:8]
+	// Generar ShortID: código aleatorio independiente del UUID, regenerado si ya existe
+	code, err := uniqueLookupCode(tx)
+	if err != nil {
+		return err
+	}
+	a.ShortID = code
+	if a.ManageToken == "" {
+		a.ManageToken = uuid.NewString()
+	}
 	return nil
 }
+
+// StartTime devuelve la hora de inicio en formato HH:MM
+func (a *Appointment) StartTime() string {
+	return FormatMinutes(a.StartMinute)
+}
+
+// StartsAt devuelve el instante de inicio de la cita, interpretando fecha y hora en loc
+func (a *Appointment) StartsAt(loc *time.Location) time.Time {
+	return DateTimeIn(a.AppointmentDate.Time, a.StartMinute, loc)
+}
+
+// EndsAt devuelve el instante de fin de la cita, interpretando fecha y hora en loc
+func (a *Appointment) EndsAt(loc *time.Location) time.Time {
+	return DateTimeIn(a.AppointmentDate.Time, a.EndMinute, loc)
+}
+
+// EndTime devuelve la hora de fin en formato HH:MM
+func (a *Appointment) EndTime() string {
+	return FormatMinutes(a.EndMinute)
+}
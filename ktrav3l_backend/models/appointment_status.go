@@ -0,0 +1,61 @@
+package models
+
+import "fmt"
+
+// appointmentTransitions es la única tabla de cambios de estado permitidos. Los estados
+// sin entrada (rejected, done, cancelled, no_show) son finales.
+var appointmentTransitions = map[AppointmentStatus][]AppointmentStatus{
+	StatusPending:  {StatusApproved, StatusRejected, StatusCancelled},
+	StatusApproved: {StatusDone, StatusNoShow, StatusCancelled},
+}
+
+// ActiveStatuses son los estados de citas que todavía pueden cambiar o moverse
+var ActiveStatuses = []AppointmentStatus{StatusPending, StatusApproved}
+
+// InactiveStatuses son los estados cuyas citas ya no ocupan lugar en la agenda
+var InactiveStatuses = []AppointmentStatus{StatusRejected, StatusCancelled}
+
+// TransitionError indica un cambio de estado no permitido
+type TransitionError struct {
+	From    AppointmentStatus
+	To      AppointmentStatus
+	Allowed []AppointmentStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("Cannot change appointment status from %s to %s", e.From, e.To)
+}
+
+// AllowedTransitions devuelve los estados a los que puede pasar una cita en este estado
+func (s AppointmentStatus) AllowedTransitions() []AppointmentStatus {
+	allowed := appointmentTransitions[s]
+	if allowed == nil {
+		return []AppointmentStatus{}
+	}
+	return allowed
+}
+
+// CanTransitionTo indica si la tabla permite pasar de s a next
+func (s AppointmentStatus) CanTransitionTo(next AppointmentStatus) bool {
+	for _, allowed := range appointmentTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive indica si la cita está pendiente o aprobada
+func (s AppointmentStatus) IsActive() bool {
+	return s == StatusPending || s == StatusApproved
+}
+
+// TransitionTo cambia el estado de la cita si la tabla lo permite; si no, devuelve un
+// *TransitionError con los estados permitidos y no modifica la cita
+func (a *Appointment) TransitionTo(next AppointmentStatus) error {
+	if !a.Status.CanTransitionTo(next) {
+		return &TransitionError{From: a.Status, To: next, Allowed: a.Status.AllowedTransitions()}
+	}
+	a.Status = next
+	return nil
+}
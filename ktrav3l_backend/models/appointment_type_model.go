@@ -6,6 +6,10 @@ import (
 
 type AppointmentType struct {
 	gorm.Model
-	Name    string `gorm:"unique;not null"`
-	Visible bool   `gorm:"default:true"`
+	Name            string `gorm:"unique;not null"`
+	Visible         bool   `gorm:"default:true"`
+	DurationMinutes int    `gorm:"not null;default:60"` // Duración de la cita en minutos
+	Capacity        *int   // Máximo de citas simultáneas de este tipo (null = sin límite propio)
+	MinLeadMinutes  *int   // Antelación mínima para reservar (null = valor global)
+	MaxHorizonDays  *int   // Días hacia adelante que se pueden reservar (null = valor global)
 }
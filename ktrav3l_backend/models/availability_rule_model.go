@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	uuid "github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -27,11 +28,27 @@ func (a *IntArray) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, a)
 }
 
-// AvailabilityRule define reglas de disponibilidad por día de la semana o fechas específicas
+// RuleMode indica si una regla cierra o abre horas
+type RuleMode string
+
+const (
+	RuleModeBlock RuleMode = "block" // Cierra UnavailableHours (o todo el día con AllDay)
+	RuleModeOpen  RuleMode = "open"  // Abre OpenHours (o todo el día con AllDay) aunque el día de semana las cierre
+)
+
+// AvailabilityRule define reglas de disponibilidad por día de la semana o fechas específicas.
+// Las reglas sin asesor aplican a todos; las de un asesor solo a su calendario.
+// Las reglas de fecha específica se aplican sobre las del día de semana: en modo
+// block cierran horas adicionales y en modo open pueden reabrir horas cerradas.
 type AvailabilityRule struct {
 	gorm.Model
 	DayOfWeek        *int       `gorm:"index"`           // 0=Domingo, 1=Lunes, ..., 6=Sábado (null para fechas específicas)
 	SpecificDate     *time.Time `gorm:"type:date;index"` // Fecha específica (null para reglas de día de semana)
 	UnavailableHours IntArray   `gorm:"type:jsonb"`      // Array de horas no disponibles [0-23]
-	AllDay           bool       `gorm:"default:false"`   // true = todo el día bloqueado
+	OpenHours        IntArray   `gorm:"type:jsonb"`      // Horas que se abren [0-23] (solo modo open)
+	AllDay           bool       `gorm:"default:false"`   // true = todo el día bloqueado (o abierto en modo open)
+	Capacity         *int       // Citas simultáneas por franja ese día (null = valor global)
+	AdvisorID        *uuid.UUID `gorm:"type:uuid;index"` // Asesor al que aplica (null = regla general)
+	// block (por defecto) u open; open solo se usa en reglas de fecha específica
+	Mode RuleMode `gorm:"type:varchar(10);not null;default:'block'"`
 }
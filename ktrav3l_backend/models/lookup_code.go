@@ -0,0 +1,73 @@
+package models
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// lookupCodeAlphabet evita caracteres que se confunden al dictarlos o copiarlos (0/O, 1/I/L, U/V)
+	lookupCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTWXYZ"
+	// LookupCodeLength da unas 2^48 combinaciones, imposibles de adivinar por fuerza bruta con rate limiting
+	LookupCodeLength = 10
+	// legacyShortIDLength es el largo de los códigos anteriores (primeros 8 hex del UUID)
+	legacyShortIDLength = 8
+	// lookupCodeAttempts es cuántas veces se regenera un código que ya existe
+	lookupCodeAttempts = 5
+)
+
+// ErrLookupCodeExhausted indica que no se encontró un código libre tras varios intentos
+var ErrLookupCodeExhausted = errors.New("could not generate a unique lookup code")
+
+// newLookupCode genera un código aleatorio con crypto/rand
+func newLookupCode() (string, error) {
+	max := big.NewInt(int64(len(lookupCodeAlphabet)))
+	code := make([]byte, LookupCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = lookupCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// uniqueLookupCode genera códigos hasta encontrar uno que no use ninguna cita
+// (incluidas las eliminadas, que siguen ocupando el índice único)
+func uniqueLookupCode(tx *gorm.DB) (string, error) {
+	for i := 0; i < lookupCodeAttempts; i++ {
+		code, err := newLookupCode()
+		if err != nil {
+			return "", err
+		}
+		var count int64
+		if err := tx.Session(&gorm.Session{NewDB: true}).Unscoped().Model(&Appointment{}).Where("short_id = ?", code).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return code, nil
+		}
+	}
+	return "", ErrLookupCodeExhausted
+}
+
+// NormalizeLookupCode ajusta un código escrito por el cliente al formato guardado:
+// los nuevos en mayúsculas y los anteriores (hex) en minúsculas
+func NormalizeLookupCode(code string) string {
+	code = strings.TrimSpace(code)
+	if IsLegacyShortID(code) {
+		return strings.ToLower(code)
+	}
+	return strings.ToUpper(code)
+}
+
+// IsLegacyShortID indica si el código es del formato anterior, que es adivinable y
+// requiere verificar además los últimos 4 dígitos del teléfono
+func IsLegacyShortID(code string) bool {
+	return len(code) == legacyShortIDLength
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending" // En cola, se envía cuando llega NextAttemptAt
+	OutboxSending OutboxStatus = "sending" // Tomado por el worker
+	OutboxSent    OutboxStatus = "sent"
+	OutboxFailed  OutboxStatus = "failed" // Agotó los reintentos; solo se reenvía a pedido del admin
+)
+
+// EmailAttachment es un archivo adjunto a un email
+type EmailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"` // Incluye parámetros, p. ej. "text/calendar; method=REQUEST"
+	Content     []byte `json:"content"`
+}
+
+// EmailAttachments guarda en jsonb los adjuntos de un email
+type EmailAttachments []EmailAttachment
+
+func (a EmailAttachments) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+func (a *EmailAttachments) Scan(value interface{}) error {
+	if value == nil {
+		*a = EmailAttachments{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+// OutboxEmail es un email pendiente o ya enviado. Los handlers solo lo guardan y el
+// worker de services lo envía con el Mailer configurado, reintentando con espera exponencial.
+type OutboxEmail struct {
+	gorm.Model
+	To            string           `gorm:"not null"`
+	Subject       string           `gorm:"not null"`
+	Body          string           `gorm:"type:text;not null"` // HTML
+	Attachments   EmailAttachments `gorm:"type:jsonb" json:"-"`
+	Status        OutboxStatus     `gorm:"type:varchar(10);not null;default:'pending';index"`
+	Attempts      int              `gorm:"not null;default:0"`
+	NextAttemptAt time.Time        `gorm:"not null;index"`
+	LastError     string
+	SentAt        *time.Time
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecurrenceKind indica cómo se repite una regla recurrente
+type RecurrenceKind string
+
+const (
+	RecurrenceYearly         RecurrenceKind = "yearly"          // Cada año en Month/Day (p. ej. 27 de febrero)
+	RecurrenceMonthlyWeekday RecurrenceKind = "monthly_weekday" // El WeekOfMonth-ésimo Weekday de cada mes (o solo de Month)
+	RecurrenceDateRange      RecurrenceKind = "date_range"      // Todos los días entre StartDate y EndDate (inclusive)
+)
+
+// RecurringRule es una regla de disponibilidad que se repite sin guardar una fila por fecha.
+// Al evaluarse se comporta como una regla de fecha específica en cada día que coincide.
+type RecurringRule struct {
+	gorm.Model
+	Name             string         `gorm:"not null"`
+	Kind             RecurrenceKind `gorm:"type:varchar(20);not null"`
+	Month            int            // 1-12 (yearly; en monthly_weekday 0 = todos los meses)
+	Day              int            // 1-31 (yearly)
+	Weekday          *int           // 0=Domingo ... 6=Sábado (monthly_weekday)
+	WeekOfMonth      int            // 1-5, -1 = último (monthly_weekday)
+	StartDate        *time.Time     `gorm:"type:date"` // Inicio del rango (date_range)
+	EndDate          *time.Time     `gorm:"type:date"` // Fin del rango, inclusive (date_range)
+	UnavailableHours IntArray       `gorm:"type:jsonb"`
+	OpenHours        IntArray       `gorm:"type:jsonb"`
+	AllDay           bool           // Sin default: GORM lo aplicaría en lugar de un false explícito
+	Mode             RuleMode       `gorm:"type:varchar(10);not null;default:'block'"`
+	AdvisorID        *uuid.UUID     `gorm:"type:uuid;index"` // null = regla general
+	ExternalUID      string         `gorm:"index"`           // UID del evento iCalendar del que se importó
+}
+
+// Matches indica si la regla aplica en la fecha dada
+func (r RecurringRule) Matches(date time.Time) bool {
+	switch r.Kind {
+	case RecurrenceYearly:
+		return int(date.Month()) == r.Month && date.Day() == r.Day
+	case RecurrenceMonthlyWeekday:
+		if r.Weekday == nil || int(date.Weekday()) != *r.Weekday {
+			return false
+		}
+		if r.Month != 0 && int(date.Month()) != r.Month {
+			return false
+		}
+		if r.WeekOfMonth == -1 {
+			return date.AddDate(0, 0, 7).Month() != date.Month()
+		}
+		return (date.Day()-1)/7+1 == r.WeekOfMonth
+	case RecurrenceDateRange:
+		if r.StartDate == nil || r.EndDate == nil {
+			return false
+		}
+		day := date.Format("2006-01-02")
+		return day >= r.StartDate.UTC().Format("2006-01-02") && day <= r.EndDate.UTC().Format("2006-01-02")
+	}
+	return false
+}
+
+// RuleFor devuelve la regla de fecha específica equivalente para un día en que la regla aplica
+func (r RecurringRule) RuleFor(date time.Time) AvailabilityRule {
+	return AvailabilityRule{
+		SpecificDate:     &date,
+		UnavailableHours: r.UnavailableHours,
+		OpenHours:        r.OpenHours,
+		AllDay:           r.AllDay,
+		Mode:             r.Mode,
+		AdvisorID:        r.AdvisorID,
+	}
+}
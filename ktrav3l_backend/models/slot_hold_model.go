@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SlotHold reserva temporalmente una franja mientras el cliente completa la
+// reserva (por ejemplo, mientras sube el comprobante). Cuenta como ocupada
+// hasta ExpiresAt o hasta que se consume al crear la cita.
+type SlotHold struct {
+	gorm.Model
+	Token             string    `gorm:"uniqueIndex;not null"`
+	AppointmentDate   DateOnly  `gorm:"not null;index"`
+	StartMinute       int       `gorm:"not null"`
+	EndMinute         int       `gorm:"not null"`
+	AppointmentTypeID uint      `gorm:"not null"`
+	ExpiresAt         time.Time `gorm:"not null;index"`
+	ClientIP          string    `gorm:"index"` // IP de quien la pidió; vacío si la creó la lista de espera
+}
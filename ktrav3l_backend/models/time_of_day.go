@@ -0,0 +1,35 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// MinutesPerDay es la cantidad de minutos de un día; los horarios de las citas
+// se guardan como minutos transcurridos desde la medianoche.
+const MinutesPerDay = 24 * 60
+
+// FormatMinutes convierte minutos desde medianoche a "HH:MM"
+func FormatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// ParseTimeOfDay convierte "HH:MM" a minutos desde medianoche
+func ParseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// DateTimeIn devuelve el instante en que ocurre el minuto indicado de una fecha
+// (DateOnly, en UTC) según la zona horaria loc
+func DateTimeIn(date time.Time, minute int, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, minute, 0, 0, loc)
+}
+
+// HourOverlaps indica si la hora completa [hour:00, hour+1:00) se solapa con el intervalo [start, end)
+func HourOverlaps(hour, start, end int) bool {
+	return hour*60 < end && hour*60+60 > start
+}
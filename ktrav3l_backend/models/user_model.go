@@ -10,4 +10,6 @@ type User struct {
 	ID       uuid.UUID `gorm:"primaryKey"`
 	Email    string    `gorm:"unique"`
 	Password string
+	// Token secreto de la URL del feed iCal de citas aprobadas (vacío hasta que se pide)
+	CalendarFeedToken string `gorm:"index" json:"-"`
 }
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"time"
+
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WaitlistStatus string
+
+const (
+	WaitlistUnconfirmed WaitlistStatus = "unconfirmed" // Se anotó pero todavía no confirmó su email
+	WaitlistWaiting     WaitlistStatus = "waiting"     // Esperando que se libere una franja
+	WaitlistNotified    WaitlistStatus = "notified"    // Se le ofreció una franja y tiene hasta ClaimExpiresAt para tomarla
+	WaitlistClaimed     WaitlistStatus = "claimed"     // Reservó la franja ofrecida
+	WaitlistExpired     WaitlistStatus = "expired"     // No tomó la franja a tiempo o pasó su rango de fechas
+	WaitlistCancelled   WaitlistStatus = "cancelled"   // El cliente salió de la lista
+)
+
+// ActiveWaitlistStatuses son los estados de quien sigue en la lista de espera
+var ActiveWaitlistStatuses = []WaitlistStatus{WaitlistUnconfirmed, WaitlistWaiting, WaitlistNotified}
+
+// WaitlistEntry es un cliente en lista de espera para un tipo de cita entre dos fechas.
+// Solo recibe franjas después de confirmar su email con ConfirmToken. Cuando se libera
+// una franja se le reserva temporalmente (SlotHold con ClaimToken) y se le envía un
+// enlace para completar la reserva antes de ClaimExpiresAt.
+type WaitlistEntry struct {
+	gorm.Model
+	ID                uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	FirstName         string          `gorm:"not null"`
+	LastName          string          `gorm:"not null"`
+	Email             string          `gorm:"not null"`
+	PhoneNumber       string          `gorm:"not null;size:12"`
+	AppointmentTypeID uint            `gorm:"not null;index"`
+	AppointmentType   AppointmentType `gorm:"foreignKey:AppointmentTypeID"`
+	FromDate          DateOnly        `gorm:"not null"`
+	ToDate            DateOnly        `gorm:"not null"` // Inclusive
+	Status            WaitlistStatus  `gorm:"type:varchar(20);not null;default:'waiting';index"`
+	ConfirmToken      string          `gorm:"index" json:"-"` // Token del enlace de confirmación del email
+	ClaimToken        string          `gorm:"index"`          // Token de la reserva temporal ofrecida
+	ClaimExpiresAt    *time.Time
+	NotifiedAt        *time.Time
+	Locale            i18n.Locale `gorm:"type:varchar(5);not null;default:'es'"` // Idioma del email de aviso
+}
+
+func (w *WaitlistEntry) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
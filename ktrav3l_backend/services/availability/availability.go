@@ -0,0 +1,213 @@
+// Package availability decide qué franjas se pueden reservar. Todas las rutas de
+// reserva (cliente, reserva temporal, mover y crear desde el admin) pasan por aquí.
+//
+// Precedencia: las reglas de fecha específica se aplican después de las de su día de
+// semana, así que ganan en caso de conflicto: en modo block cierran horas adicionales
+// y en modo open reabren horas que el día de semana cierra. Las franjas pasadas nunca
+// se pueden reservar. Con Options.Override (solo admin) se ignoran las reglas y la
+// ventana de reserva, pero no el cupo ni los asesores.
+package availability
+
+import (
+	"errors"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDayBlocked           = errors.New("This day is blocked")
+	ErrSlotBlocked          = errors.New("This time slot is blocked")
+	ErrPastTime             = errors.New("Cannot book a past hour")
+	ErrOutsideBookingWindow = errors.New("Selected time is outside the booking window")
+	ErrSlotFull             = errors.New("Time slot not available")
+	ErrAdvisorNotFound      = errors.New("Advisor not found")
+	ErrAdvisorUnavailable   = errors.New("Advisor not available at this time")
+)
+
+// Options ajusta las verificaciones de una reserva
+type Options struct {
+	Override bool // El admin reserva aunque las reglas o la ventana de reserva lo impidan
+}
+
+// Slot es una franja que todavía admite reservas
+type Slot struct {
+	Start int
+	End   int
+	Seats int
+}
+
+// IsSlotAvailable verifica si [start, end) se puede reservar para el tipo de cita.
+// Devuelve nil o el motivo del rechazo (uno de los errores del paquete).
+func (s Schedule) IsSlotAvailable(start, end int, appointmentType models.AppointmentType, opts Options) error {
+	if start <= elapsedMinutes(s.date) {
+		return ErrPastTime
+	}
+	if !opts.Override {
+		if err := s.checkRules(start, end); err != nil {
+			return err
+		}
+		if !WithinBookingWindow(s.date, start, appointmentType) {
+			return ErrOutsideBookingWindow
+		}
+	}
+	if s.RemainingSeats(start, end, appointmentType) <= 0 {
+		return ErrSlotFull
+	}
+	return nil
+}
+
+// AvailableSlots recorre las franjas del día para un tipo de cita de la duración dada.
+// Devuelve las que tienen lugar libre y cuántas franjas permitían las reglas y los
+// horarios (aunque estén llenas), para distinguir un día bloqueado de uno completo.
+func (s Schedule) AvailableSlots(appointmentType models.AppointmentType, duration int) ([]Slot, int) {
+	slots := []Slot{}
+	allowed := 0
+
+	for start := 0; start+duration <= models.MinutesPerDay; start += config.Env.SlotIntervalMinutes {
+		end := start + duration
+		err := s.IsSlotAvailable(start, end, appointmentType, Options{})
+		if err != nil && !errors.Is(err, ErrSlotFull) {
+			continue
+		}
+		allowed++
+
+		// Los lugares libres ya consideran la unión de asesores disponibles
+		if err == nil {
+			slots = append(slots, Slot{Start: start, End: end, Seats: s.RemainingSeats(start, end, appointmentType)})
+		}
+	}
+
+	return slots, allowed
+}
+
+// NextAvailable busca la primera franja libre para la cita desde su fecha hasta maxDays
+// días después, conservando su tipo y duración. La propia cita no ocupa lugar.
+func NextAvailable(db *gorm.DB, appointment models.Appointment, maxDays int) (time.Time, int, bool) {
+	from := dayOf(appointment.AppointmentDate.Time)
+	to := from.AddDate(0, 0, maxDays)
+	schedules := LoadRange(db, from, to, appointment.ID.String())
+	duration := appointment.EndMinute - appointment.StartMinute
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		slots, _ := schedules[day.Format("2006-01-02")].AvailableSlots(appointment.AppointmentType, duration)
+		if len(slots) > 0 {
+			return day, slots[0].Start, true
+		}
+	}
+	return time.Time{}, 0, false
+}
+
+// checkRules verifica que las reglas generales del día no bloqueen ninguna parte de [start, end)
+func (s Schedule) checkRules(start, end int) error {
+	blocked := blockedHours(s.weekdayRules, s.specificDateRules)
+
+	allDay := true
+	for _, closed := range blocked {
+		allDay = allDay && closed
+	}
+	if allDay {
+		return ErrDayBlocked
+	}
+
+	for hour, closed := range blocked {
+		if closed && models.HourOverlaps(hour, start, end) {
+			return ErrSlotBlocked
+		}
+	}
+	return nil
+}
+
+// blockedHours combina las reglas de un día en las horas cerradas (0-23): primero
+// las de día de semana y luego las de fecha específica, que pueden cerrar o reabrir
+func blockedHours(weekdayRules, specificDateRules []models.AvailabilityRule) [24]bool {
+	var blocked [24]bool
+	for _, rule := range weekdayRules {
+		applyRule(&blocked, rule)
+	}
+	for _, rule := range specificDateRules {
+		applyRule(&blocked, rule)
+	}
+	return blocked
+}
+
+func applyRule(blocked *[24]bool, rule models.AvailabilityRule) {
+	if rule.Mode == models.RuleModeOpen {
+		if rule.AllDay {
+			*blocked = [24]bool{}
+		}
+		setHours(blocked, rule.OpenHours, false)
+	} else if rule.AllDay {
+		for hour := range blocked {
+			blocked[hour] = true
+		}
+	}
+	setHours(blocked, rule.UnavailableHours, true)
+}
+
+func setHours(blocked *[24]bool, hours []int, closed bool) {
+	for _, hour := range hours {
+		if hour >= 0 && hour < len(blocked) {
+			blocked[hour] = closed
+		}
+	}
+}
+
+// elapsedMinutes devuelve cuántos minutos de una fecha ya transcurrieron en la zona
+// horaria del negocio: -1 si la fecha es futura y MinutesPerDay si ya pasó completa.
+// Una franja que empieza en un minuto <= a este valor está en el pasado.
+func elapsedMinutes(date time.Time) int {
+	now := config.Env.BusinessNow()
+	today := dayOf(now)
+	day := dayOf(date)
+
+	switch {
+	case day.Before(today):
+		return models.MinutesPerDay
+	case day.After(today):
+		return -1
+	default:
+		return now.Hour()*60 + now.Minute()
+	}
+}
+
+// BookingWindow devuelve la antelación mínima (minutos) y el horizonte máximo
+// (días, 0 = sin límite) de un tipo de cita, usando los valores globales si no define los suyos
+func BookingWindow(appointmentType models.AppointmentType) (int, int) {
+	lead := config.Env.MinLeadMinutes
+	if appointmentType.MinLeadMinutes != nil {
+		lead = *appointmentType.MinLeadMinutes
+	}
+	horizon := config.Env.MaxHorizonDays
+	if appointmentType.MaxHorizonDays != nil {
+		horizon = *appointmentType.MaxHorizonDays
+	}
+	return lead, horizon
+}
+
+// BookingDateRange devuelve la primera y la última fecha reservables de un tipo de cita.
+// La última es nil si no hay horizonte máximo.
+func BookingDateRange(appointmentType models.AppointmentType) (time.Time, *time.Time) {
+	lead, horizon := BookingWindow(appointmentType)
+	now := config.Env.BusinessNow()
+	first := dayOf(now.Add(time.Duration(lead) * time.Minute))
+	if horizon <= 0 {
+		return first, nil
+	}
+	last := dayOf(now).AddDate(0, 0, horizon)
+	return first, &last
+}
+
+// WithinBookingWindow indica si una franja respeta la antelación mínima y el horizonte máximo del tipo
+func WithinBookingWindow(date time.Time, start int, appointmentType models.AppointmentType) bool {
+	lead, _ := BookingWindow(appointmentType)
+	earliest := config.Env.BusinessNow().Add(time.Duration(lead) * time.Minute)
+	if !models.DateTimeIn(date, start, config.Env.BusinessLocation).After(earliest) {
+		return false
+	}
+
+	_, last := BookingDateRange(appointmentType)
+	return last == nil || !dayOf(date).After(*last)
+}
@@ -0,0 +1,376 @@
+package availability
+
+import (
+	"errors"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"reflect"
+	"testing"
+	"time"
+
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func setupConfig(t *testing.T) {
+	t.Helper()
+	config.Env = &config.EnvConfig{
+		SlotIntervalMinutes: 60,
+		SlotCapacity:        1,
+		BusinessTimezone:    "UTC",
+		BusinessLocation:    time.UTC,
+	}
+}
+
+// futureDay devuelve una fecha dentro de days días, a medianoche UTC
+func futureDay(days int) time.Time {
+	return dayOf(time.Now().UTC()).AddDate(0, 0, days)
+}
+
+func gormModel(id uint) gorm.Model {
+	return gorm.Model{ID: id}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func weekdayRule(date time.Time, rule models.AvailabilityRule) models.AvailabilityRule {
+	weekday := int(date.Weekday())
+	rule.DayOfWeek = &weekday
+	return rule
+}
+
+func appointment(start, end int) models.Appointment {
+	return models.Appointment{StartMinute: start, EndMinute: end, AppointmentTypeID: 1, Status: models.StatusApproved}
+}
+
+func TestIsSlotAvailable(t *testing.T) {
+	setupConfig(t)
+	day := futureDay(7)
+	visa := models.AppointmentType{Model: gormModel(1), DurationMinutes: 60}
+	advisorA := models.Advisor{ID: uuid.New(), Name: "A", IsActive: true}
+	advisorB := models.Advisor{ID: uuid.New(), Name: "B", IsActive: true}
+
+	tests := []struct {
+		name            string
+		schedule        Schedule
+		start, end      int
+		appointmentType models.AppointmentType
+		opts            Options
+		minLead         int
+		maxHorizon      int
+		want            error
+	}{
+		{
+			name:     "open day",
+			schedule: Schedule{date: day},
+			start:    600, end: 660,
+		},
+		{
+			name:     "past day",
+			schedule: Schedule{date: futureDay(-1)},
+			start:    600, end: 660,
+			want: ErrPastTime,
+		},
+		{
+			name:     "past day with override",
+			schedule: Schedule{date: futureDay(-1)},
+			start:    600, end: 660,
+			opts: Options{Override: true},
+			want: ErrPastTime,
+		},
+		{
+			name: "weekday rule blocks the hour",
+			schedule: Schedule{date: day, weekdayRules: []models.AvailabilityRule{
+				weekdayRule(day, models.AvailabilityRule{UnavailableHours: models.IntArray{10}}),
+			}},
+			start: 600, end: 660,
+			want: ErrSlotBlocked,
+		},
+		{
+			name: "slot partially inside a blocked hour",
+			schedule: Schedule{date: day, weekdayRules: []models.AvailabilityRule{
+				weekdayRule(day, models.AvailabilityRule{UnavailableHours: models.IntArray{11}}),
+			}},
+			start: 630, end: 690,
+			want: ErrSlotBlocked,
+		},
+		{
+			name: "weekday rule blocks the whole day",
+			schedule: Schedule{date: day, weekdayRules: []models.AvailabilityRule{
+				weekdayRule(day, models.AvailabilityRule{AllDay: true}),
+			}},
+			start: 600, end: 660,
+			want: ErrDayBlocked,
+		},
+		{
+			name: "specific date open rule reopens a closed weekday",
+			schedule: Schedule{date: day,
+				weekdayRules: []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{AllDay: true})},
+				specificDateRules: []models.AvailabilityRule{
+					{SpecificDate: &day, Mode: models.RuleModeOpen, OpenHours: models.IntArray{10}},
+				},
+			},
+			start: 600, end: 660,
+		},
+		{
+			name: "open rule does not reopen other hours",
+			schedule: Schedule{date: day,
+				weekdayRules: []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{AllDay: true})},
+				specificDateRules: []models.AvailabilityRule{
+					{SpecificDate: &day, Mode: models.RuleModeOpen, OpenHours: models.IntArray{10}},
+				},
+			},
+			start: 660, end: 720,
+			want: ErrSlotBlocked,
+		},
+		{
+			name: "specific date block closes extra hours",
+			schedule: Schedule{date: day,
+				weekdayRules: []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{UnavailableHours: models.IntArray{8}})},
+				specificDateRules: []models.AvailabilityRule{
+					{SpecificDate: &day, Mode: models.RuleModeBlock, UnavailableHours: models.IntArray{10}},
+				},
+			},
+			start: 600, end: 660,
+			want: ErrSlotBlocked,
+		},
+		{
+			name: "override ignores rules",
+			schedule: Schedule{date: day, weekdayRules: []models.AvailabilityRule{
+				weekdayRule(day, models.AvailabilityRule{AllDay: true}),
+			}},
+			start: 600, end: 660,
+			opts: Options{Override: true},
+		},
+		{
+			name:     "slot full",
+			schedule: Schedule{date: day, appointments: []models.Appointment{appointment(600, 660)}},
+			start:    600, end: 660,
+			want: ErrSlotFull,
+		},
+		{
+			name:     "override does not ignore capacity",
+			schedule: Schedule{date: day, appointments: []models.Appointment{appointment(600, 660)}},
+			start:    600, end: 660,
+			opts: Options{Override: true},
+			want: ErrSlotFull,
+		},
+		{
+			name:     "overlapping appointment fills the slot",
+			schedule: Schedule{date: day, appointments: []models.Appointment{appointment(630, 720)}},
+			start:    600, end: 660,
+			want: ErrSlotFull,
+		},
+		{
+			name:     "adjacent appointment does not overlap",
+			schedule: Schedule{date: day, appointments: []models.Appointment{appointment(540, 600)}},
+			start:    600, end: 660,
+		},
+		{
+			name:     "hold occupies the slot",
+			schedule: Schedule{date: day, holds: []models.SlotHold{{Token: "t", StartMinute: 600, EndMinute: 660, AppointmentTypeID: 1}}},
+			start:    600, end: 660,
+			want: ErrSlotFull,
+		},
+		{
+			name: "hold released for its owner",
+			schedule: Schedule{date: day, holds: []models.SlotHold{{Token: "t", StartMinute: 600, EndMinute: 660, AppointmentTypeID: 1}}}.
+				WithoutHold("t"),
+			start: 600, end: 660,
+		},
+		{
+			name: "day capacity rule allows a second appointment",
+			schedule: Schedule{date: day,
+				weekdayRules: []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{Capacity: intPtr(2)})},
+				appointments: []models.Appointment{appointment(600, 660)},
+			},
+			start: 600, end: 660,
+		},
+		{
+			name: "specific date capacity overrides the weekday",
+			schedule: Schedule{date: day,
+				weekdayRules:      []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{Capacity: intPtr(3)})},
+				specificDateRules: []models.AvailabilityRule{{SpecificDate: &day, Capacity: intPtr(1)}},
+				appointments:      []models.Appointment{appointment(600, 660)},
+			},
+			start: 600, end: 660,
+			want: ErrSlotFull,
+		},
+		{
+			name: "appointment type capacity",
+			schedule: Schedule{date: day,
+				weekdayRules: []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{Capacity: intPtr(5)})},
+				appointments: []models.Appointment{appointment(600, 660)},
+			},
+			appointmentType: models.AppointmentType{Model: gormModel(1), DurationMinutes: 60, Capacity: intPtr(1)},
+			start:           600, end: 660,
+			want: ErrSlotFull,
+		},
+		{
+			name:     "minimum lead time",
+			schedule: Schedule{date: futureDay(1)},
+			start:    600, end: 660,
+			minLead: 3 * 24 * 60,
+			want:    ErrOutsideBookingWindow,
+		},
+		{
+			name:     "appointment type lead time overrides the global one",
+			schedule: Schedule{date: futureDay(1)},
+			start:    600, end: 660,
+			minLead:         3 * 24 * 60,
+			appointmentType: models.AppointmentType{Model: gormModel(1), DurationMinutes: 60, MinLeadMinutes: intPtr(0)},
+		},
+		{
+			name:     "beyond the horizon",
+			schedule: Schedule{date: futureDay(30)},
+			start:    600, end: 660,
+			maxHorizon: 10,
+			want:       ErrOutsideBookingWindow,
+		},
+		{
+			name:     "override ignores the booking window",
+			schedule: Schedule{date: futureDay(30)},
+			start:    600, end: 660,
+			maxHorizon: 10,
+			opts:       Options{Override: true},
+		},
+		{
+			name: "only advisor is busy",
+			schedule: Schedule{date: day,
+				weekdayRules: []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{Capacity: intPtr(5)})},
+				advisors:     []models.Advisor{advisorA},
+				appointments: []models.Appointment{withAdvisor(appointment(600, 660), advisorA.ID)},
+			},
+			start: 600, end: 660,
+			want: ErrSlotFull,
+		},
+		{
+			name: "second advisor is free",
+			schedule: Schedule{date: day,
+				weekdayRules: []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{Capacity: intPtr(5)})},
+				advisors:     []models.Advisor{advisorA, advisorB},
+				appointments: []models.Appointment{withAdvisor(appointment(600, 660), advisorA.ID)},
+			},
+			start: 600, end: 660,
+		},
+		{
+			name: "unassigned appointment takes the free advisor",
+			schedule: Schedule{date: day,
+				weekdayRules: []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{Capacity: intPtr(5)})},
+				advisors:     []models.Advisor{advisorA, advisorB},
+				appointments: []models.Appointment{withAdvisor(appointment(600, 660), advisorA.ID), appointment(600, 660)},
+			},
+			start: 600, end: 660,
+			want: ErrSlotFull,
+		},
+		{
+			name: "advisor blocked by their own rule",
+			schedule: Schedule{date: day,
+				advisors: []models.Advisor{advisorA},
+				advisorRules: []models.AvailabilityRule{
+					weekdayRule(day, models.AvailabilityRule{AdvisorID: &advisorA.ID, UnavailableHours: models.IntArray{10}}),
+				},
+			},
+			start: 600, end: 660,
+			want: ErrSlotFull,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Env.MinLeadMinutes = tt.minLead
+			config.Env.MaxHorizonDays = tt.maxHorizon
+			appointmentType := tt.appointmentType
+			if appointmentType.ID == 0 {
+				appointmentType = visa
+			}
+
+			err := tt.schedule.IsSlotAvailable(tt.start, tt.end, appointmentType, tt.opts)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("IsSlotAvailable() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailableSlots(t *testing.T) {
+	setupConfig(t)
+	day := futureDay(7)
+	visa := models.AppointmentType{Model: gormModel(1), DurationMinutes: 60}
+
+	// Solo abierto de 9 a 12
+	closed := models.IntArray{}
+	for hour := 0; hour < 24; hour++ {
+		if hour < 9 || hour >= 12 {
+			closed = append(closed, hour)
+		}
+	}
+	morning := weekdayRule(day, models.AvailabilityRule{UnavailableHours: closed})
+
+	tests := []struct {
+		name        string
+		schedule    Schedule
+		duration    int
+		interval    int
+		wantStarts  []int
+		wantAllowed int
+	}{
+		{
+			name:        "hourly slots in the open hours",
+			schedule:    Schedule{date: day, weekdayRules: []models.AvailabilityRule{morning}},
+			duration:    60,
+			interval:    60,
+			wantStarts:  []int{540, 600, 660},
+			wantAllowed: 3,
+		},
+		{
+			name:        "full slot counts as allowed but is not offered",
+			schedule:    Schedule{date: day, weekdayRules: []models.AvailabilityRule{morning}, appointments: []models.Appointment{appointment(600, 660)}},
+			duration:    60,
+			interval:    60,
+			wantStarts:  []int{540, 660},
+			wantAllowed: 3,
+		},
+		{
+			name:        "longer appointments must fit in the open hours",
+			schedule:    Schedule{date: day, weekdayRules: []models.AvailabilityRule{morning}},
+			duration:    90,
+			interval:    30,
+			wantStarts:  []int{540, 570, 600, 630},
+			wantAllowed: 4,
+		},
+		{
+			name:        "blocked day",
+			schedule:    Schedule{date: day, weekdayRules: []models.AvailabilityRule{weekdayRule(day, models.AvailabilityRule{AllDay: true})}},
+			duration:    60,
+			interval:    60,
+			wantStarts:  []int{},
+			wantAllowed: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Env.SlotIntervalMinutes = tt.interval
+			slots, allowed := tt.schedule.AvailableSlots(visa, tt.duration)
+
+			starts := []int{}
+			for _, slot := range slots {
+				starts = append(starts, slot.Start)
+				if slot.End != slot.Start+tt.duration || slot.Seats != 1 {
+					t.Errorf("slot %+v: want end %d and 1 seat", slot, slot.Start+tt.duration)
+				}
+			}
+			if !reflect.DeepEqual(starts, tt.wantStarts) || allowed != tt.wantAllowed {
+				t.Fatalf("AvailableSlots() starts = %v, allowed = %d; want %v, %d", starts, allowed, tt.wantStarts, tt.wantAllowed)
+			}
+		})
+	}
+}
+
+func withAdvisor(apt models.Appointment, advisorID uuid.UUID) models.Appointment {
+	apt.AdvisorID = &advisorID
+	return apt
+}
@@ -0,0 +1,296 @@
+package availability
+
+import (
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"time"
+
+	uuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Schedule reúne lo que define la disponibilidad de un día: reglas generales,
+// asesores activos con sus reglas propias y las citas ya tomadas
+type Schedule struct {
+	date              time.Time                 // Fecha del día (medianoche UTC)
+	weekdayRules      []models.AvailabilityRule // Reglas generales del día de semana
+	specificDateRules []models.AvailabilityRule // Reglas generales de la fecha
+	advisorRules      []models.AvailabilityRule // Reglas propias de cada asesor para ese día
+	advisors          []models.Advisor          // Asesores activos
+	appointments      []models.Appointment      // Citas del día que ocupan lugar (ni rechazadas ni canceladas)
+	holds             []models.SlotHold         // Reservas temporales vigentes del día
+}
+
+// Load carga la agenda de una fecha usando db (la conexión o una transacción).
+// excludeID permite ignorar una cita (la que se está moviendo o aprobando); vacío para no excluir ninguna.
+func Load(db *gorm.DB, date time.Time, excludeID string) Schedule {
+	return LoadRange(db, date, date, excludeID)[date.Format("2006-01-02")]
+}
+
+// LoadRange carga la agenda de cada fecha entre from y to (inclusive), indexada por
+// "YYYY-MM-DD". Hace una consulta por tabla para todo el rango y reparte los resultados por día.
+func LoadRange(db *gorm.DB, from, to time.Time, excludeID string) map[string]Schedule {
+	from = dayOf(from)
+	to = dayOf(to)
+
+	var rules []models.AvailabilityRule
+	var recurringRules []models.RecurringRule
+	var advisors []models.Advisor
+	var appointments []models.Appointment
+	var holds []models.SlotHold
+
+	db.Where("day_of_week IS NOT NULL OR specific_date BETWEEN ? AND ?", from, to).Find(&rules)
+	db.Where("kind <> ? OR (start_date <= ? AND end_date >= ?)", models.RecurrenceDateRange, to, from).
+		Find(&recurringRules)
+	db.Where("is_active = ?", true).Order("name ASC").Find(&advisors)
+
+	query := db.Where("appointment_date BETWEEN ? AND ? AND status NOT IN ?", from, to, models.InactiveStatuses)
+	if excludeID != "" {
+		query = query.Where("id != ?", excludeID)
+	}
+	query.Find(&appointments)
+
+	db.Where("appointment_date BETWEEN ? AND ? AND expires_at > ?", from, to, time.Now()).Find(&holds)
+
+	schedules := make(map[string]Schedule)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		s := Schedule{date: day, advisors: advisors}
+		dayOfWeek := int(day.Weekday())
+		key := day.Format("2006-01-02")
+
+		// Las reglas recurrentes que coinciden se evalúan como reglas de fecha
+		// específica, antes de las creadas para esa fecha en particular
+		for _, recurring := range recurringRules {
+			if !recurring.Matches(day) {
+				continue
+			}
+			if rule := recurring.RuleFor(day); rule.AdvisorID != nil {
+				s.advisorRules = append(s.advisorRules, rule)
+			} else {
+				s.specificDateRules = append(s.specificDateRules, rule)
+			}
+		}
+
+		for _, rule := range rules {
+			matchesWeekday := rule.DayOfWeek != nil && *rule.DayOfWeek == dayOfWeek
+			matchesDate := rule.SpecificDate != nil && rule.SpecificDate.UTC().Format("2006-01-02") == key
+			switch {
+			case rule.AdvisorID != nil && (matchesWeekday || matchesDate):
+				s.advisorRules = append(s.advisorRules, rule)
+			case matchesWeekday:
+				s.weekdayRules = append(s.weekdayRules, rule)
+			case matchesDate:
+				s.specificDateRules = append(s.specificDateRules, rule)
+			}
+		}
+		for _, appointment := range appointments {
+			if appointment.AppointmentDate.Time.UTC().Format("2006-01-02") == key {
+				s.appointments = append(s.appointments, appointment)
+			}
+		}
+		for _, hold := range holds {
+			if hold.AppointmentDate.Time.UTC().Format("2006-01-02") == key {
+				s.holds = append(s.holds, hold)
+			}
+		}
+
+		schedules[key] = s
+	}
+
+	return schedules
+}
+
+// WithoutHold devuelve la agenda sin la reserva temporal indicada, para que
+// quien la tiene pueda usar su propio lugar
+func (s Schedule) WithoutHold(token string) Schedule {
+	if token == "" {
+		return s
+	}
+	holds := []models.SlotHold{}
+	for _, hold := range s.holds {
+		if hold.Token != token {
+			holds = append(holds, hold)
+		}
+	}
+	s.holds = holds
+	return s
+}
+
+// occupied devuelve las citas del día junto con las reservas temporales,
+// que ocupan lugar como una cita sin asesor asignado
+func (s Schedule) occupied() []models.Appointment {
+	occupied := append([]models.Appointment{}, s.appointments...)
+	for _, hold := range s.holds {
+		occupied = append(occupied, models.Appointment{
+			StartMinute:       hold.StartMinute,
+			EndMinute:         hold.EndMinute,
+			AppointmentTypeID: hold.AppointmentTypeID,
+		})
+	}
+	return occupied
+}
+
+// Capacity calcula el cupo por franja del día. La regla de fecha específica
+// tiene prioridad sobre la de día de semana, y ésta sobre el valor global.
+func (s Schedule) Capacity() int {
+	capacity := config.Env.SlotCapacity
+	for _, rule := range s.weekdayRules {
+		if rule.Capacity != nil {
+			capacity = *rule.Capacity
+		}
+	}
+	for _, rule := range s.specificDateRules {
+		if rule.Capacity != nil {
+			capacity = *rule.Capacity
+		}
+	}
+	return capacity
+}
+
+// RemainingSeats devuelve cuántos lugares quedan en [start, end) para un tipo de cita,
+// considerando el cupo del día, el cupo propio del tipo y los asesores libres
+func (s Schedule) RemainingSeats(start, end int, appointmentType models.AppointmentType) int {
+	occupied := s.occupied()
+	seats := s.Capacity() - peakOverlap(occupied, start, end, 0)
+	if appointmentType.Capacity != nil {
+		seats = min(seats, *appointmentType.Capacity-peakOverlap(occupied, start, end, appointmentType.ID))
+	}
+	if len(s.advisors) > 0 {
+		seats = min(seats, s.advisorSeats(start, end))
+	}
+	return max(seats, 0)
+}
+
+// advisorSeats devuelve cuántos asesores pueden tomar una cita nueva en [start, end):
+// los asesores libres menos las citas del intervalo que aún no tienen asesor
+func (s Schedule) advisorSeats(start, end int) int {
+	var unassigned []models.Appointment
+	for _, apt := range s.occupied() {
+		if apt.AdvisorID == nil {
+			unassigned = append(unassigned, apt)
+		}
+	}
+	return len(s.freeAdvisors(start, end)) - peakOverlap(unassigned, start, end, 0)
+}
+
+// freeAdvisors devuelve los asesores sin bloqueo propio ni cita asignada en [start, end)
+func (s Schedule) freeAdvisors(start, end int) []models.Advisor {
+	free := []models.Advisor{}
+	for _, advisor := range s.advisors {
+		if s.AdvisorFree(advisor.ID, start, end) {
+			free = append(free, advisor)
+		}
+	}
+	return free
+}
+
+// AdvisorFree indica si el asesor no tiene bloqueo propio ni otra cita asignada en [start, end)
+func (s Schedule) AdvisorFree(advisorID uuid.UUID, start, end int) bool {
+	if s.advisorBlocked(advisorID, start, end) {
+		return false
+	}
+	for _, apt := range s.appointments {
+		if apt.AdvisorID != nil && *apt.AdvisorID == advisorID && apt.StartMinute < end && apt.EndMinute > start {
+			return false
+		}
+	}
+	return true
+}
+
+// advisorBlocked indica si las reglas propias del asesor cierran alguna parte de [start, end)
+func (s Schedule) advisorBlocked(advisorID uuid.UUID, start, end int) bool {
+	var weekdayRules, specificDateRules []models.AvailabilityRule
+	for _, rule := range s.advisorRules {
+		if *rule.AdvisorID != advisorID {
+			continue
+		}
+		if rule.SpecificDate != nil {
+			specificDateRules = append(specificDateRules, rule)
+		} else {
+			weekdayRules = append(weekdayRules, rule)
+		}
+	}
+	for hour, closed := range blockedHours(weekdayRules, specificDateRules) {
+		if closed && models.HourOverlaps(hour, start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// Conflicts devuelve las citas pendientes o aprobadas del día que las reglas ya no
+// permiten: las generales o, si tienen asesor asignado, las propias de ese asesor
+func (s Schedule) Conflicts() []models.Appointment {
+	conflicts := []models.Appointment{}
+	for _, apt := range s.appointments {
+		if !apt.Status.IsActive() {
+			continue
+		}
+		if s.checkRules(apt.StartMinute, apt.EndMinute) != nil ||
+			(apt.AdvisorID != nil && s.advisorBlocked(*apt.AdvisorID, apt.StartMinute, apt.EndMinute)) {
+			conflicts = append(conflicts, apt)
+		}
+	}
+	return conflicts
+}
+
+// PickAdvisor valida el asesor solicitado o, si no se indica ninguno, elige el primero
+// libre en [start, end). Devuelve nil cuando no hay asesores configurados.
+func (s Schedule) PickAdvisor(requested string, start, end int) (*uuid.UUID, error) {
+	if requested == "" {
+		free := s.freeAdvisors(start, end)
+		if len(free) == 0 {
+			if len(s.advisors) == 0 {
+				return nil, nil
+			}
+			return nil, ErrAdvisorUnavailable
+		}
+		return &free[0].ID, nil
+	}
+
+	advisorID, err := uuid.Parse(requested)
+	if err != nil {
+		return nil, ErrAdvisorNotFound
+	}
+	for _, advisor := range s.advisors {
+		if advisor.ID == advisorID {
+			if !s.AdvisorFree(advisorID, start, end) {
+				return nil, ErrAdvisorUnavailable
+			}
+			return &advisorID, nil
+		}
+	}
+	return nil, ErrAdvisorNotFound
+}
+
+// peakOverlap devuelve el máximo de citas simultáneas dentro del intervalo [start, end).
+// Si typeID no es 0 solo se cuentan las citas de ese tipo.
+func peakOverlap(appointments []models.Appointment, start, end int, typeID uint) int {
+	// La ocupación solo puede aumentar al inicio del intervalo o cuando empieza una cita
+	points := []int{start}
+	for _, apt := range appointments {
+		if apt.StartMinute > start && apt.StartMinute < end {
+			points = append(points, apt.StartMinute)
+		}
+	}
+
+	peak := 0
+	for _, point := range points {
+		count := 0
+		for _, apt := range appointments {
+			if typeID != 0 && apt.AppointmentTypeID != typeID {
+				continue
+			}
+			if apt.StartMinute <= point && apt.EndMinute > point {
+				count++
+			}
+		}
+		peak = max(peak, count)
+	}
+	return peak
+}
+
+// dayOf devuelve la fecha como medianoche UTC, la forma en que se guardan las fechas de citas
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
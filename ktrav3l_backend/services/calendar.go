@@ -0,0 +1,79 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services/ical"
+	"strings"
+)
+
+// AppointmentCalendarEvent arma el evento de calendario de una cita. Con includeMeeting
+// se agregan el enlace, la plataforma y la nota del admin (solo para quien ya verificó
+// ser el cliente o para el admin); la cita debe tener precargados AppointmentType y,
+// si aplica, MeetingPlatform. La descripción se escribe en locale.
+func AppointmentCalendarEvent(appointment *models.Appointment, includeMeeting bool, locale i18n.Locale) ical.Event {
+	description := []string{i18n.T(locale, "calendar.code", appointment.ShortID)}
+	event := ical.Event{
+		UID:     appointment.ID.String() + "@ktrav3l",
+		Summary: "KTravel - " + appointment.AppointmentType.Name,
+		Start:   appointment.StartsAt(config.Env.BusinessLocation),
+		End:     appointment.EndsAt(config.Env.BusinessLocation),
+	}
+
+	if includeMeeting {
+		if appointment.MeetingPlatform != nil {
+			event.Location = appointment.MeetingPlatform.Name
+			description = append(description, i18n.T(locale, "calendar.platform", appointment.MeetingPlatform.Name))
+		}
+		if appointment.MeetingLink != "" {
+			event.URL = appointment.MeetingLink
+			event.Location = appointment.MeetingLink
+			description = append(description, i18n.T(locale, "calendar.meetingLink", appointment.MeetingLink))
+		}
+		if appointment.AdminNote != "" {
+			description = append(description, i18n.T(locale, "calendar.note", appointment.AdminNote))
+		}
+	}
+
+	event.Description = strings.Join(description, "\n")
+	return event
+}
+
+// calendarInvite arma el .ics de la invitación de la cita para adjuntarlo a un email.
+// REQUEST crea o actualiza el evento en el calendario del cliente y CANCEL lo elimina;
+// el UID es siempre el mismo y CalendarSequence indica cuál es la versión más reciente.
+func calendarInvite(appointment *models.Appointment, method string) (models.EmailAttachment, error) {
+	event := AppointmentCalendarEvent(appointment, true, appointment.Locale.OrDefault())
+	event.Organizer = config.Env.SMTPFrom
+	event.Attendee = appointment.Email
+	event.Sequence = appointment.CalendarSequence
+	event.Status = "CONFIRMED"
+	if method == ical.MethodCancel {
+		event.Status = "CANCELLED"
+	}
+
+	var buf bytes.Buffer
+	if err := (ical.Calendar{Method: method, Events: []ical.Event{event}}).Write(&buf); err != nil {
+		return models.EmailAttachment{}, err
+	}
+
+	return models.EmailAttachment{
+		Filename:    "invite.ics",
+		ContentType: "text/calendar; charset=UTF-8; method=" + method,
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+// calendarInvites devuelve la invitación como lista de adjuntos; si no se pudo armar
+// el email se envía igual, sin adjunto
+func calendarInvites(appointment *models.Appointment, method string) []models.EmailAttachment {
+	invite, err := calendarInvite(appointment, method)
+	if err != nil {
+		fmt.Println("Error building calendar invite:", err)
+		return nil
+	}
+	return []models.EmailAttachment{invite}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"fmt"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"time"
+)
+
+const (
+	// emailBatchSize es cuántos emails envía el worker en cada pasada
+	emailBatchSize = 20
+	// emailBaseBackoff es la espera tras el primer fallo; se duplica en cada intento
+	emailBaseBackoff = time.Minute
+	// emailMaxBackoff limita la espera entre reintentos
+	emailMaxBackoff = time.Hour
+	// emailStuckAfter es cuánto puede quedar un email en sending antes de volver a la cola
+	// (por ejemplo, si el servidor se reinició mientras lo enviaba)
+	emailStuckAfter = 10 * time.Minute
+)
+
+// emailWakeup despierta al worker apenas se encola un email, sin esperar al ticker
+var emailWakeup = make(chan struct{}, 1)
+
+// enqueueEmail guarda un email en la outbox para que el worker lo envíe
+func enqueueEmail(to, subject, body string, attachments []models.EmailAttachment) error {
+	email := models.OutboxEmail{
+		To:            to,
+		Subject:       subject,
+		Body:          body,
+		Attachments:   attachments,
+		Status:        models.OutboxPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := initializers.DB.Create(&email).Error; err != nil {
+		return err
+	}
+	WakeEmailWorker()
+	return nil
+}
+
+// WakeEmailWorker pide al worker una pasada inmediata (por ejemplo, tras un reintento manual)
+func WakeEmailWorker() {
+	select {
+	case emailWakeup <- struct{}{}:
+	default:
+	}
+}
+
+// StartEmailWorker envía en segundo plano los emails de la outbox cada interval o
+// apenas se encola uno. Los fallos se reintentan con espera exponencial hasta
+// EMAIL_MAX_ATTEMPTS y luego quedan en failed para que el admin los reenvíe.
+func StartEmailWorker(interval time.Duration) {
+	// Lo que quedó a medio enviar en una ejecución anterior vuelve a la cola
+	initializers.DB.Model(&models.OutboxEmail{}).
+		Where("status = ? AND updated_at < ?", models.OutboxSending, time.Now().Add(-emailStuckAfter)).
+		Update("status", models.OutboxPending)
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			processOutbox()
+			select {
+			case <-ticker.C:
+			case <-emailWakeup:
+			}
+		}
+	}()
+}
+
+// processOutbox envía los emails pendientes cuyo próximo intento ya llegó
+func processOutbox() {
+	var emails []models.OutboxEmail
+	initializers.DB.Where("status = ? AND next_attempt_at <= ?", models.OutboxPending, time.Now()).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(emailBatchSize).
+		Find(&emails)
+
+	for _, email := range emails {
+		// Tomar el email solo si nadie más lo tomó
+		result := initializers.DB.Model(&models.OutboxEmail{}).
+			Where("id = ? AND status = ?", email.ID, models.OutboxPending).
+			Update("status", models.OutboxSending)
+		if result.Error != nil || result.RowsAffected == 0 {
+			continue
+		}
+
+		updates := map[string]interface{}{"attempts": email.Attempts + 1}
+		if err := deliverEmail(email); err != nil {
+			updates["last_error"] = err.Error()
+			if email.Attempts+1 >= config.Env.EmailMaxAttempts {
+				updates["status"] = models.OutboxFailed
+				fmt.Println("Email", email.ID, "to", email.To, "failed permanently:", err)
+			} else {
+				updates["status"] = models.OutboxPending
+				updates["next_attempt_at"] = time.Now().Add(emailBackoff(email.Attempts + 1))
+			}
+		} else {
+			updates["status"] = models.OutboxSent
+			updates["sent_at"] = time.Now()
+			updates["last_error"] = ""
+		}
+
+		if err := initializers.DB.Model(&models.OutboxEmail{}).Where("id = ?", email.ID).Updates(updates).Error; err != nil {
+			fmt.Println("Error updating outbox email:", err)
+		}
+	}
+}
+
+// emailBackoff devuelve la espera antes del siguiente intento: 1, 2, 4, 8... minutos, hasta 1 hora
+func emailBackoff(attempts int) time.Duration {
+	wait := emailBaseBackoff
+	for i := 1; i < attempts && wait < emailMaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > emailMaxBackoff {
+		wait = emailMaxBackoff
+	}
+	return wait
+}
+
+// deliverEmail entrega un email de la outbox con el Mailer configurado
+func deliverEmail(email models.OutboxEmail) error {
+	return initializers.Mailer.Send(newMessage(email.To, email.Subject, email.Body, email.Attachments))
+}
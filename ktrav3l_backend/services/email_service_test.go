@@ -0,0 +1,192 @@
+package services
+
+import (
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"pixelbrew-llc/ktrav3l_backend/services/mailer"
+	"strings"
+	"testing"
+	"time"
+
+	uuid "github.com/google/uuid"
+)
+
+func testAppointment(locale i18n.Locale) models.Appointment {
+	return models.Appointment{
+		ID:              uuid.New(),
+		ShortID:         "K7M2XQ9P",
+		FirstName:       "Ana",
+		LastName:        "Pérez",
+		Email:           "ana@ktravel.test",
+		PhoneNumber:     "8095551234",
+		AppointmentDate: models.NewDateOnly(time.Now().UTC().AddDate(0, 0, 7)),
+		StartMinute:     600,
+		EndMinute:       660,
+		AppointmentType: models.AppointmentType{Name: "Visa", DurationMinutes: 60},
+		Status:          models.StatusPending,
+		ManageToken:     uuid.NewString(),
+		Locale:          locale,
+	}
+}
+
+func setupEmailTest(t *testing.T) (*EmailService, *mailer.Memory) {
+	t.Helper()
+	config.Env = &config.EnvConfig{
+		FrontendURL:              "http://localhost:3000",
+		SMTPFrom:                 "citas@ktravel.test",
+		SMTPFromName:             "KTravel",
+		SelfServiceCutoffMinutes: 1440,
+		BusinessTimezone:         "UTC",
+		BusinessLocation:         time.UTC,
+	}
+	memory := mailer.NewMemory()
+	return NewEmailServiceWithMailer(memory), memory
+}
+
+func TestSendAppointmentConfirmation(t *testing.T) {
+	for _, locale := range []i18n.Locale{i18n.Spanish, i18n.English, i18n.Italian} {
+		t.Run(string(locale), func(t *testing.T) {
+			service, memory := setupEmailTest(t)
+			appointment := testAppointment(locale)
+
+			if err := service.SendAppointmentConfirmation(&appointment); err != nil {
+				t.Fatalf("SendAppointmentConfirmation() error = %v", err)
+			}
+
+			msg, ok := memory.Last()
+			if !ok {
+				t.Fatal("no email sent")
+			}
+			if msg.To != appointment.Email {
+				t.Errorf("To = %q, want %q", msg.To, appointment.Email)
+			}
+			if want := i18n.T(locale, "email.confirmation.subject", appointment.ShortID); msg.Subject != want {
+				t.Errorf("Subject = %q, want %q", msg.Subject, want)
+			}
+			for _, want := range []string{appointment.ShortID, "Ana", appointment.ManageToken, i18n.T(locale, "email.confirmation.title")} {
+				if !strings.Contains(msg.HTML, want) {
+					t.Errorf("body does not contain %q", want)
+				}
+			}
+			if len(msg.Attachments) != 0 {
+				t.Errorf("got %d attachments, want none for a pending booking", len(msg.Attachments))
+			}
+		})
+	}
+}
+
+func TestSendAppointmentRejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		wasApproved bool
+		wantInvite  bool
+	}{
+		{name: "pending booking has no invite to cancel", wasApproved: false, wantInvite: false},
+		{name: "approved appointment removes the invite", wasApproved: true, wantInvite: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, memory := setupEmailTest(t)
+			appointment := testAppointment(i18n.Spanish)
+			appointment.CalendarSequence = 2
+
+			if err := service.SendAppointmentRejected(&appointment, "Comprobante ilegible", tt.wasApproved); err != nil {
+				t.Fatalf("SendAppointmentRejected() error = %v", err)
+			}
+
+			sent := memory.SentTo(appointment.Email)
+			if len(sent) != 1 {
+				t.Fatalf("sent %d emails to the client, want 1", len(sent))
+			}
+			msg := sent[0]
+			if want := i18n.T(i18n.Spanish, "email.rejected.subject", appointment.ShortID); msg.Subject != want {
+				t.Errorf("Subject = %q, want %q", msg.Subject, want)
+			}
+			if !strings.Contains(msg.HTML, "Comprobante ilegible") {
+				t.Error("body does not contain the rejection reason")
+			}
+			if !tt.wantInvite {
+				if len(msg.Attachments) != 0 {
+					t.Errorf("got %d attachments, want none", len(msg.Attachments))
+				}
+				return
+			}
+			if len(msg.Attachments) != 1 || !strings.Contains(msg.Attachments[0].ContentType, "method=CANCEL") {
+				t.Fatalf("attachments = %+v, want one CANCEL invite", msg.Attachments)
+			}
+			if invite := string(msg.Attachments[0].Content); !strings.Contains(invite, "SEQUENCE:2") || !strings.Contains(invite, "STATUS:CANCELLED") {
+				t.Errorf("invite does not cancel sequence 2:\n%s", invite)
+			}
+		})
+	}
+}
+
+func TestSendAppointmentCancelled(t *testing.T) {
+	tests := []struct {
+		name        string
+		wasApproved bool
+		wantInvite  bool
+	}{
+		{name: "approved appointment removes the invite", wasApproved: true, wantInvite: true},
+		{name: "pending appointment has no invite", wasApproved: false, wantInvite: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, memory := setupEmailTest(t)
+			appointment := testAppointment(i18n.English)
+			appointment.Status = models.StatusCancelled
+			appointment.CalendarSequence = 1
+
+			if err := service.SendAppointmentCancelled(&appointment, tt.wasApproved); err != nil {
+				t.Fatalf("SendAppointmentCancelled() error = %v", err)
+			}
+
+			msg, ok := memory.Last()
+			if !ok {
+				t.Fatal("no email sent")
+			}
+			if msg.To != appointment.Email {
+				t.Errorf("To = %q, want %q", msg.To, appointment.Email)
+			}
+			if want := i18n.T(i18n.English, "email.cancelled.subject", appointment.ShortID); msg.Subject != want {
+				t.Errorf("Subject = %q, want %q", msg.Subject, want)
+			}
+			if !strings.Contains(msg.HTML, i18n.T(i18n.English, "email.status.cancelled")) {
+				t.Error("body does not show the cancelled status")
+			}
+
+			hasInvite := len(msg.Attachments) == 1 && strings.Contains(msg.Attachments[0].ContentType, "method=CANCEL") &&
+				strings.Contains(string(msg.Attachments[0].Content), "SEQUENCE:1")
+			if hasInvite != tt.wantInvite {
+				t.Errorf("attachments = %+v, want CANCEL invite: %v", msg.Attachments, tt.wantInvite)
+			}
+		})
+	}
+}
+
+func TestSendAppointmentCancelledByBusinessShowsReason(t *testing.T) {
+	service, memory := setupEmailTest(t)
+	appointment := testAppointment(i18n.English)
+	appointment.Status = models.StatusCancelled
+	appointment.RejectionReason = "The office is closed that day"
+
+	if err := service.SendAppointmentCancelled(&appointment, true); err != nil {
+		t.Fatalf("SendAppointmentCancelled() error = %v", err)
+	}
+
+	msg, ok := memory.Last()
+	if !ok {
+		t.Fatal("no email sent")
+	}
+	for _, want := range []string{appointment.RejectionReason, i18n.T(i18n.English, "email.cancelled.businessIntro")} {
+		if !strings.Contains(msg.HTML, want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+	if strings.Contains(msg.HTML, i18n.T(i18n.English, "email.cancelled.intro")) {
+		t.Error("body says the client cancelled the appointment")
+	}
+}
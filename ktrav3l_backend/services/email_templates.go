@@ -0,0 +1,76 @@
+package services
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"pixelbrew-llc/ktrav3l_backend/config"
+	"pixelbrew-llc/ktrav3l_backend/i18n"
+	"time"
+)
+
+// emailTemplateFiles son las plantillas incluidas en el binario. Con EMAIL_TEMPLATES_DIR
+// se puede reemplazar cualquiera de ellas sin recompilar: si el directorio tiene un archivo
+// con el mismo nombre se usa ese, y se vuelve a leer en cada envío.
+//
+//go:embed templates/email/*.html
+var emailTemplateFiles embed.FS
+
+// emailLayout define el "layout" común; cada email define "title" y "content"
+const emailLayout = "layout.html"
+
+// emailFuncs son las funciones disponibles en las plantillas; los textos, fechas y horas
+// salen en el idioma del email
+func emailFuncs(locale i18n.Locale) template.FuncMap {
+	return template.FuncMap{
+		"frontendURL": func() string { return config.Env.FrontendURL },
+		"locale":      func() string { return string(locale) },
+		"t": func(key string, args ...interface{}) string {
+			return i18n.T(locale, key, args...)
+		},
+		"date":  func(t time.Time) string { return i18n.FormatDate(locale, t) },
+		"time":  func(minutes int) string { return i18n.FormatTime(locale, minutes) },
+		"phone": formatPhone,
+	}
+}
+
+// renderEmail arma el HTML de un email en locale con el layout y la plantilla name.
+// html/template escapa todos los datos, así que nombres, notas o enlaces no pueden
+// inyectar HTML.
+func renderEmail(locale i18n.Locale, name string, data interface{}) (string, error) {
+	tmpl := template.New("email").Funcs(emailFuncs(locale.OrDefault())).Option("missingkey=error")
+	for _, file := range []string{emailLayout, name} {
+		content, err := readEmailTemplate(file)
+		if err != nil {
+			return "", err
+		}
+		if _, err := tmpl.New(file).Parse(string(content)); err != nil {
+			return "", fmt.Errorf("email template %s: %w", file, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return "", fmt.Errorf("email template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// readEmailTemplate lee la plantilla de EMAIL_TEMPLATES_DIR si existe ahí, o la incluida
+func readEmailTemplate(name string) ([]byte, error) {
+	if dir := config.Env.EmailTemplatesDir; dir != "" {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return content, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return emailTemplateFiles.ReadFile("templates/email/" + name)
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"fmt"
+	"pixelbrew-llc/ktrav3l_backend/initializers"
+	"pixelbrew-llc/ktrav3l_backend/models"
+	"time"
+)
+
+// StartHoldSweeper elimina en segundo plano, cada interval, las reservas
+// temporales de franjas que ya vencieron
+func StartHoldSweeper(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for range ticker.C {
+			sweepExpiredHolds()
+		}
+	}()
+}
+
+func sweepExpiredHolds() {
+	result := initializers.DB.Unscoped().Where("expires_at <= ?", time.Now()).Delete(&models.SlotHold{})
+	if result.Error != nil {
+		fmt.Println("Error sweeping expired holds:", result.Error)
+	}
+}
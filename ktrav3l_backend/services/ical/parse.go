@@ -0,0 +1,284 @@
+// Package ical lee y escribe archivos iCalendar (RFC 5545) con lo mínimo que usa la
+// aplicación: eventos de día completo o con hora, su UID, resumen y recurrencia (RRULE y
+// EXDATE) al leer, y eventos con descripción, lugar y enlace al escribir.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event es un VEVENT de un calendario. Al leer solo se completan UID, Summary, Start,
+// End, AllDay, Recurrence, ExDates y RecurrenceID.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Organizer   string      // Email del organizador (invitaciones REQUEST/CANCEL)
+	Attendee    string      // Email del invitado (invitaciones REQUEST/CANCEL)
+	Sequence    int         // Se incrementa en cada cambio de una invitación ya enviada
+	Status      string      // CONFIRMED o CANCELLED; vacío para no indicarlo
+	Start       time.Time   // Fecha de inicio (medianoche UTC para eventos de día completo)
+	End         time.Time   // Fin exclusivo; igual a Start si el evento no lo indica
+	AllDay      bool        // DTSTART es una fecha (VALUE=DATE) y no una fecha y hora
+	Recurrence  *Recurrence // RRULE; nil si el evento no se repite
+	ExDates     []time.Time // Fechas excluidas de la recurrencia (EXDATE), a medianoche UTC
+	// RecurrenceID es la repetición que este evento modifica dentro del evento recurrente
+	// con el mismo UID (RECURRENCE-ID); cero si no es una repetición modificada
+	RecurrenceID time.Time
+}
+
+// Frecuencias de RRULE que se pueden expandir
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxOccurrences limita cuántas fechas se generan al expandir una recurrencia finita
+const maxOccurrences = 1000
+
+// Recurrence es la RRULE de un evento. Solo se interpretan FREQ, INTERVAL, COUNT y UNTIL;
+// las demás partes (BYDAY, BYSETPOS...) quedan en Unsupported para que quien importa
+// rechace el evento en lugar de generar fechas distintas a las del calendario original.
+type Recurrence struct {
+	Raw         string    // Valor original, para informar por qué se rechazó
+	Freq        string    // DAILY, WEEKLY, MONTHLY, YEARLY u otra
+	Interval    int       // Cada cuántas unidades de Freq se repite (1 si no se indica)
+	Count       int       // Cantidad de repeticiones; 0 = sin límite
+	Until       time.Time // Última fecha posible, a medianoche UTC; cero = sin límite
+	Unsupported []string  // Partes no interpretadas o inválidas
+}
+
+// Bounded indica si la recurrencia termina (COUNT o UNTIL)
+func (r Recurrence) Bounded() bool {
+	return r.Count > 0 || !r.Until.IsZero()
+}
+
+// Supported indica si la recurrencia se puede expandir con Occurrences
+func (r Recurrence) Supported() bool {
+	switch r.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+		return len(r.Unsupported) == 0
+	}
+	return false
+}
+
+var (
+	// ErrUnsupportedRecurrence indica que la RRULE usa partes que no se interpretan
+	ErrUnsupportedRecurrence = errors.New("unsupported recurrence rule")
+	// ErrUnboundedRecurrence indica que la RRULE no tiene COUNT ni UNTIL y no se puede expandir
+	ErrUnboundedRecurrence = errors.New("recurrence rule has no COUNT or UNTIL")
+	// ErrTooManyOccurrences indica que la recurrencia genera más de maxOccurrences fechas
+	ErrTooManyOccurrences = errors.New("recurrence rule has too many occurrences")
+)
+
+// Occurrences devuelve las fechas de inicio (medianoche UTC) de un evento: solo Start si
+// no se repite, o cada repetición de una recurrencia finita sin las fechas de EXDATE.
+// Como indica RFC 5545, las fechas que no existen (31 de un mes corto, 29 de febrero
+// en un año no bisiesto) se saltan en lugar de moverse al mes siguiente.
+func (e Event) Occurrences() ([]time.Time, error) {
+	start := dateOf(e.Start)
+	if e.Recurrence == nil {
+		return []time.Time{start}, nil
+	}
+	r := *e.Recurrence
+	if !r.Supported() {
+		return nil, ErrUnsupportedRecurrence
+	}
+	if !r.Bounded() {
+		return nil, ErrUnboundedRecurrence
+	}
+
+	excluded := map[time.Time]bool{}
+	for _, date := range e.ExDates {
+		excluded[dateOf(date)] = true
+	}
+
+	var dates []time.Time
+	generated := 0
+	for step := 0; ; step++ {
+		if step > maxOccurrences*12 {
+			return nil, ErrTooManyOccurrences
+		}
+		date, ok := r.nth(start, step)
+		if !ok {
+			continue
+		}
+		if !r.Until.IsZero() && date.After(r.Until) {
+			break
+		}
+		// COUNT incluye las fechas que después excluye EXDATE
+		generated++
+		if r.Count > 0 && generated > r.Count {
+			break
+		}
+		if !excluded[date] {
+			dates = append(dates, date)
+		}
+		if len(dates) > maxOccurrences {
+			return nil, ErrTooManyOccurrences
+		}
+	}
+	return dates, nil
+}
+
+// nth devuelve la step-ésima repetición a partir de start; ok es false si esa fecha no existe
+func (r Recurrence) nth(start time.Time, step int) (time.Time, bool) {
+	n := step * r.Interval
+	var date time.Time
+	switch r.Freq {
+	case FreqDaily:
+		return start.AddDate(0, 0, n), true
+	case FreqWeekly:
+		return start.AddDate(0, 0, 7*n), true
+	case FreqMonthly:
+		date = time.Date(start.Year(), start.Month()+time.Month(n), start.Day(), 0, 0, 0, 0, time.UTC)
+	case FreqYearly:
+		date = time.Date(start.Year()+n, start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return date, date.Day() == start.Day()
+}
+
+// ErrNoEvents indica que el archivo no contiene ningún VEVENT válido
+var ErrNoEvents = errors.New("calendar has no events")
+
+// Parse lee los eventos de un archivo iCalendar. Los eventos sin DTSTART se ignoran.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	for _, line := range lines {
+		name, value := splitProperty(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+		case name == "END" && value == "VEVENT":
+			if current != nil && !current.Start.IsZero() {
+				if current.End.IsZero() {
+					current.End = current.Start
+				}
+				events = append(events, *current)
+			}
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "DTSTART":
+			current.Start, err = parseDate(value)
+			current.AllDay = len(value) == 8
+		case name == "DTEND":
+			current.End, err = parseDate(value)
+		case name == "RECURRENCE-ID":
+			current.RecurrenceID, err = parseDate(value)
+		case name == "RRULE":
+			current.Recurrence = parseRecurrence(value)
+		case name == "EXDATE":
+			for _, item := range strings.Split(value, ",") {
+				var date time.Time
+				if date, err = parseDate(item); err != nil {
+					break
+				}
+				current.ExDates = append(current.ExDates, date)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(events) == 0 {
+		return nil, ErrNoEvents
+	}
+	return events, nil
+}
+
+// parseRecurrence interpreta el valor de una RRULE ("FREQ=YEARLY;COUNT=3")
+func parseRecurrence(value string) *Recurrence {
+	r := &Recurrence{Raw: value, Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(val)
+			if err == nil && r.Interval < 1 {
+				err = errors.New("invalid interval")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(val)
+			if err == nil && r.Count < 1 {
+				err = errors.New("invalid count")
+			}
+		case "UNTIL":
+			r.Until, err = parseDate(val)
+		case "WKST":
+			// Solo afecta a BYWEEKNO y a WEEKLY con BYDAY, que no se interpretan
+		default:
+			r.Unsupported = append(r.Unsupported, part)
+		}
+		if err != nil {
+			r.Unsupported = append(r.Unsupported, part)
+		}
+	}
+	return r
+}
+
+// unfold une las líneas partidas: una línea que empieza con espacio o tab continúa la anterior
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// splitProperty separa "NOMBRE;PARAM=X:valor" en nombre y valor; los parámetros se ignoran
+func splitProperty(line string) (string, string) {
+	head, value, _ := strings.Cut(line, ":")
+	name, _, _ := strings.Cut(head, ";")
+	return strings.ToUpper(name), value
+}
+
+// parseDate interpreta DATE (YYYYMMDD) o DATE-TIME (YYYYMMDDTHHMMSS[Z]) y
+// devuelve solo la fecha, a medianoche UTC
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, errors.New("invalid date: " + value)
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+// dateOf devuelve la fecha a medianoche UTC
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// unescapeText deshace los escapes de TEXT (\, \; \n \\)
+func unescapeText(value string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, "\n", `\N`, "\n", `\\`, `\`).Replace(value)
+}
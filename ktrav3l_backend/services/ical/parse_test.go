@@ -0,0 +1,105 @@
+package ical
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func dates(values ...string) []time.Time {
+	result := []time.Time{}
+	for _, value := range values {
+		result = append(result, date(value))
+	}
+	return result
+}
+
+func parseEvent(t *testing.T, properties ...string) Event {
+	t.Helper()
+	calendar := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:feriado@test\r\nSUMMARY:Feriado\r\n" +
+		strings.Join(properties, "\r\n") + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	events, err := Parse(strings.NewReader(calendar))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return events[0]
+}
+
+func TestParseRecurrence(t *testing.T) {
+	event := parseEvent(t,
+		"DTSTART;VALUE=DATE:20250227",
+		"RRULE:FREQ=YEARLY;INTERVAL=2;COUNT=3;UNTIL=20301231T235959Z;WKST=MO",
+		"EXDATE;VALUE=DATE:20270227,20290227",
+		"RECURRENCE-ID;VALUE=DATE:20250227",
+	)
+
+	want := &Recurrence{
+		Raw:      "FREQ=YEARLY;INTERVAL=2;COUNT=3;UNTIL=20301231T235959Z;WKST=MO",
+		Freq:     FreqYearly,
+		Interval: 2,
+		Count:    3,
+		Until:    date("2030-12-31"),
+	}
+	if !reflect.DeepEqual(event.Recurrence, want) {
+		t.Errorf("Recurrence = %+v, want %+v", event.Recurrence, want)
+	}
+	if !reflect.DeepEqual(event.ExDates, dates("2027-02-27", "2029-02-27")) {
+		t.Errorf("ExDates = %v", event.ExDates)
+	}
+	if !event.RecurrenceID.Equal(date("2025-02-27")) {
+		t.Errorf("RecurrenceID = %v", event.RecurrenceID)
+	}
+
+	unsupported := parseEvent(t, "DTSTART;VALUE=DATE:20250227", "RRULE:FREQ=MONTHLY;BYDAY=2MO;COUNT=x")
+	if got := unsupported.Recurrence.Unsupported; !reflect.DeepEqual(got, []string{"BYDAY=2MO", "COUNT=x"}) {
+		t.Errorf("Unsupported = %v", got)
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		rrule   string
+		exdates []string
+		want    []time.Time
+		wantErr error
+	}{
+		{name: "no recurrence", start: "2025-02-27", want: dates("2025-02-27")},
+		{name: "yearly count", start: "2025-02-27", rrule: "FREQ=YEARLY;COUNT=3", want: dates("2025-02-27", "2026-02-27", "2027-02-27")},
+		{name: "yearly until", start: "2025-02-27", rrule: "FREQ=YEARLY;UNTIL=20270227", want: dates("2025-02-27", "2026-02-27", "2027-02-27")},
+		{name: "yearly skips missing leap days", start: "2024-02-29", rrule: "FREQ=YEARLY;UNTIL=20290101", want: dates("2024-02-29", "2028-02-29")},
+		{name: "monthly skips short months", start: "2025-01-31", rrule: "FREQ=MONTHLY;COUNT=4", want: dates("2025-01-31", "2025-03-31", "2025-05-31", "2025-07-31")},
+		{name: "weekly with interval", start: "2025-03-03", rrule: "FREQ=WEEKLY;INTERVAL=2;COUNT=3", want: dates("2025-03-03", "2025-03-17", "2025-03-31")},
+		{name: "daily with exdate", start: "2025-12-24", rrule: "FREQ=DAILY;COUNT=3", exdates: []string{"2025-12-25"}, want: dates("2025-12-24", "2025-12-26")},
+		{name: "count and until, until first", start: "2025-01-01", rrule: "FREQ=DAILY;COUNT=10;UNTIL=20250102", want: dates("2025-01-01", "2025-01-02")},
+		{name: "unbounded", start: "2025-02-27", rrule: "FREQ=YEARLY", wantErr: ErrUnboundedRecurrence},
+		{name: "by rules", start: "2025-01-06", rrule: "FREQ=MONTHLY;BYDAY=1MO;COUNT=3", wantErr: ErrUnsupportedRecurrence},
+		{name: "unknown frequency", start: "2025-01-06", rrule: "FREQ=HOURLY;COUNT=3", wantErr: ErrUnsupportedRecurrence},
+		{name: "too many", start: "2025-01-01", rrule: "FREQ=DAILY;UNTIL=20351231", wantErr: ErrTooManyOccurrences},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := Event{Start: date(tt.start), ExDates: dates(tt.exdates...)}
+			if tt.rrule != "" {
+				event.Recurrence = parseRecurrence(tt.rrule)
+			}
+
+			got, err := event.Occurrences()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Occurrences() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
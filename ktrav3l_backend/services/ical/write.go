@@ -0,0 +1,112 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Métodos iTIP (RFC 5546) de un calendario
+const (
+	MethodPublish = "PUBLISH" // Calendario para ver o suscribirse
+	MethodRequest = "REQUEST" // Invitación nueva o actualizada
+	MethodCancel  = "CANCEL"  // Cancelación de una invitación enviada
+)
+
+// Calendar es un VCALENDAR a escribir
+type Calendar struct {
+	Method string // Uno de los Method*; PUBLISH si está vacío
+	Name   string // Nombre que muestran las apps al suscribirse (X-WR-CALNAME), opcional
+	Events []Event
+}
+
+// prodID identifica a la aplicación como generadora de los calendarios
+const prodID = "-//KTravel//Reservas//ES"
+
+// maxLineOctets es el largo máximo de una línea antes de partirla (RFC 5545, 3.1)
+const maxLineOctets = 75
+
+// Write escribe el calendario. Los eventos con hora se escriben en UTC; los de día
+// completo como fechas.
+func (cal Calendar) Write(w io.Writer) error {
+	method := cal.Method
+	if method == "" {
+		method = MethodPublish
+	}
+
+	buf := bufio.NewWriter(w)
+	writeLine(buf, "BEGIN:VCALENDAR")
+	writeLine(buf, "VERSION:2.0")
+	writeLine(buf, "PRODID:"+prodID)
+	writeLine(buf, "CALSCALE:GREGORIAN")
+	writeLine(buf, "METHOD:"+method)
+	if cal.Name != "" {
+		writeLine(buf, "X-WR-CALNAME:"+escapeText(cal.Name))
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, event := range cal.Events {
+		writeLine(buf, "BEGIN:VEVENT")
+		writeLine(buf, "UID:"+event.UID)
+		writeLine(buf, "DTSTAMP:"+stamp)
+		writeLine(buf, "SEQUENCE:"+strconv.Itoa(event.Sequence))
+		if event.Status != "" {
+			writeLine(buf, "STATUS:"+event.Status)
+		}
+		if event.Organizer != "" {
+			writeLine(buf, "ORGANIZER:mailto:"+event.Organizer)
+		}
+		if event.Attendee != "" {
+			writeLine(buf, "ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:"+event.Attendee)
+		}
+		if event.AllDay {
+			writeLine(buf, "DTSTART;VALUE=DATE:"+event.Start.Format("20060102"))
+			writeLine(buf, "DTEND;VALUE=DATE:"+event.End.Format("20060102"))
+		} else {
+			writeLine(buf, "DTSTART:"+event.Start.UTC().Format("20060102T150405Z"))
+			writeLine(buf, "DTEND:"+event.End.UTC().Format("20060102T150405Z"))
+		}
+		writeLine(buf, "SUMMARY:"+escapeText(event.Summary))
+		if event.Description != "" {
+			writeLine(buf, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(buf, "LOCATION:"+escapeText(event.Location))
+		}
+		if event.URL != "" {
+			writeLine(buf, "URL:"+event.URL)
+		}
+		writeLine(buf, "END:VEVENT")
+	}
+
+	writeLine(buf, "END:VCALENDAR")
+	return buf.Flush()
+}
+
+// writeLine escribe una línea terminada en CRLF, partida cada 75 octetos sin cortar
+// caracteres UTF-8; las continuaciones empiezan con un espacio
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // El espacio inicial cuenta en el largo
+	}
+	w.WriteString(line + "\r\n")
+}
+
+// isRuneStart indica si el byte no es la continuación de un carácter UTF-8
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// escapeText aplica los escapes de TEXT, inverso de unescapeText
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// fileCounter distingue mensajes guardados en el mismo nanosegundo
+var fileCounter uint64
+
+// File guarda cada mensaje como un archivo .eml en un maildir (tmp/, new/, cur/), para
+// revisar los emails en desarrollo sin un servidor SMTP. Se pueden abrir con cualquier
+// cliente de correo o apuntar uno compatible con maildir al directorio.
+type File struct {
+	Dir string
+}
+
+func NewFile(dir string) *File {
+	return &File{Dir: dir}
+}
+
+func (m *File) Send(msg Message) error {
+	data, err := Build(msg)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(m.Dir, sub), 0o755); err != nil {
+			return err
+		}
+	}
+
+	// Se escribe en tmp/ y se mueve a new/ para que nadie lea un archivo a medias
+	name := fmt.Sprintf("%d.%d_%d.ktrav3l.eml", time.Now().Unix(), time.Now().UnixNano(), atomic.AddUint64(&fileCounter, 1))
+	tmpPath := filepath.Join(m.Dir, "tmp", name)
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Join(m.Dir, "new", name))
+}
@@ -0,0 +1,192 @@
+// Package mailer entrega emails ya armados. Mailer es la interfaz que usa el servicio de
+// emails; hay una implementación por SMTP para producción, una que los guarda como archivos
+// en un maildir para desarrollo local y una en memoria para tests.
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Address es un remitente o destinatario con su nombre opcional
+type Address struct {
+	Name    string
+	Address string
+}
+
+// String devuelve la dirección para un header, con el nombre codificado según RFC 2047
+// si tiene acentos u otros caracteres no ASCII
+func (a Address) String() string {
+	return (&mail.Address{Name: a.Name, Address: a.Address}).String()
+}
+
+// Attachment es un archivo adjunto
+type Attachment struct {
+	Filename    string
+	ContentType string // Incluye parámetros, p. ej. "text/calendar; method=REQUEST"
+	Content     []byte
+}
+
+// Message es un email listo para enviar con su cuerpo HTML ya renderizado. Si Text está
+// vacío, la versión en texto plano se genera a partir del HTML.
+type Message struct {
+	From        Address
+	ReplyTo     Address // Opcional
+	To          string
+	Subject     string
+	HTML        string
+	Text        string
+	Attachments []Attachment
+}
+
+// Mailer entrega un mensaje. Devuelve error si no se pudo entregar, para que quien lo
+// llama decida si reintentar.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// Build arma el mensaje completo en formato RFC 5322, listo para SMTP o para guardar
+// como .eml: headers codificados según RFC 2047, el cuerpo como multipart/alternative
+// (texto plano y HTML) y, si hay adjuntos, todo dentro de un multipart/mixed.
+func Build(msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", msg.From.String())
+	if msg.ReplyTo.Address != "" {
+		writeHeader(&buf, "Reply-To", msg.ReplyTo.String())
+	}
+	writeHeader(&buf, "To", msg.To)
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", stripNewlines(msg.Subject)))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", newMessageID(msg.From.Address))
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	text := msg.Text
+	if text == "" {
+		text = PlainText(msg.HTML)
+	}
+
+	alternativeType, alternative, err := buildAlternative(text, msg.HTML)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(msg.Attachments) == 0 {
+		writeHeader(&buf, "Content-Type", alternativeType)
+		buf.WriteString("\r\n")
+		buf.Write(alternative)
+		return buf.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buf)
+	writeHeader(&buf, "Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": writer.Boundary()}))
+	buf.WriteString("\r\n")
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {alternativeType}})
+	if err != nil {
+		return nil, err
+	}
+	part.Write(alternative)
+
+	for _, attachment := range msg.Attachments {
+		if err := writeAttachment(writer, attachment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeHeader escribe un header quitando saltos de línea, para que ningún valor pueda
+// agregar headers propios
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name + ": " + stripNewlines(value) + "\r\n")
+}
+
+func stripNewlines(value string) string {
+	return strings.NewReplacer("\r", "", "\n", " ").Replace(value)
+}
+
+// buildAlternative arma un cuerpo multipart/alternative con el texto plano primero y el
+// HTML después (los clientes muestran la última parte que entienden) y devuelve también
+// su Content-Type con el boundary
+func buildAlternative(text, html string) (string, []byte, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	for _, body := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	} {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {body.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		qp := quotedprintable.NewWriter(part)
+		if _, err := qp.Write([]byte(body.content)); err != nil {
+			return "", nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return "", nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", nil, err
+	}
+
+	contentType := mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": writer.Boundary()})
+	return contentType, buf.Bytes(), nil
+}
+
+// writeAttachment agrega un adjunto en base64 con el nombre de archivo codificado
+func writeAttachment(writer *multipart.Writer, attachment Attachment) error {
+	mediaType, params, err := mime.ParseMediaType(attachment.ContentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", map[string]string{}
+	}
+	params["name"] = attachment.Filename
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(mediaType, params)},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+	})
+	if err != nil {
+		return err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+	// Líneas de 76 caracteres (RFC 2045)
+	for len(encoded) > 76 {
+		part.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	_, err = part.Write([]byte(encoded))
+	return err
+}
+
+// newMessageID genera un Message-ID único con el dominio del remitente
+func newMessageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 && at < len(from)-1 {
+		domain = from[at+1:]
+	}
+	random := make([]byte, 12)
+	rand.Read(random)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(random), domain)
+}
@@ -0,0 +1,65 @@
+package mailer
+
+import (
+	"strings"
+	"sync"
+)
+
+// Memory guarda los mensajes en memoria en lugar de enviarlos, para que los tests
+// verifiquen destinatarios, asuntos y cuerpos renderizados. Con Err se simula un fallo.
+type Memory struct {
+	mu       sync.Mutex
+	messages []Message
+	Err      error // Si no es nil, Send lo devuelve sin guardar el mensaje
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Err != nil {
+		return m.Err
+	}
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages devuelve una copia de los mensajes enviados, en orden
+func (m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+// Last devuelve el último mensaje enviado
+func (m *Memory) Last() (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.messages) == 0 {
+		return Message{}, false
+	}
+	return m.messages[len(m.messages)-1], true
+}
+
+// SentTo devuelve los mensajes enviados a un destinatario
+func (m *Memory) SentTo(address string) []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []Message
+	for _, msg := range m.messages {
+		if strings.EqualFold(msg.To, address) {
+			result = append(result, msg)
+		}
+	}
+	return result
+}
+
+// Reset borra los mensajes guardados
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = nil
+}
@@ -0,0 +1,110 @@
+package mailer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// Security es cómo se cifra la conexión con el servidor SMTP
+type Security string
+
+const (
+	SecurityStartTLS Security = "starttls" // Conexión en claro que pasa a TLS con STARTTLS (puerto 587)
+	SecurityTLS      Security = "tls"      // TLS desde el inicio (puerto 465)
+	SecurityNone     Security = "none"     // Sin cifrar, solo para servidores locales de prueba
+)
+
+// smtpTimeout limita cuánto se espera al conectar con el servidor
+const smtpTimeout = 30 * time.Second
+
+// SMTP entrega los mensajes a un servidor SMTP con autenticación PLAIN
+type SMTP struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	Security Security
+}
+
+func NewSMTP(host, port, username, password string, security Security) *SMTP {
+	return &SMTP{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		Security: security,
+	}
+}
+
+func (m *SMTP) Send(msg Message) error {
+	data, err := Build(msg)
+	if err != nil {
+		return err
+	}
+
+	client, err := m.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if m.Security == SecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp: %s does not support STARTTLS", m.Host)
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: m.Host}); err != nil {
+			return err
+		}
+	}
+
+	if m.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(msg.From.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// dial abre la conexión, cifrada desde el inicio si Security es tls
+func (m *SMTP) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.Host, m.Port)
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+
+	var conn net.Conn
+	var err error
+	if m.Security == SecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: m.Host})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlHiddenPattern  = regexp.MustCompile(`(?is)<(head|style|script)[^>]*>.*?</(head|style|script)>`)
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlLinkPattern    = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	htmlBreakPattern   = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlBlockPattern   = regexp.MustCompile(`(?i)</?(p|div|h[1-6]|tr|li|table)(\s[^>]*)?>`)
+	htmlTagPattern     = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern  = regexp.MustCompile(`\s+`)
+	blankLinesPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// PlainText genera la versión en texto plano de un email HTML: quita estilos y etiquetas,
+// convierte bloques y <br> en saltos de línea y deja los enlaces como "texto (url)"
+func PlainText(body string) string {
+	text := htmlHiddenPattern.ReplaceAllString(body, "")
+	text = htmlCommentPattern.ReplaceAllString(text, "")
+	text = htmlLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := htmlLinkPattern.FindStringSubmatch(link)
+		href := html.UnescapeString(match[1])
+		label := strings.TrimSpace(htmlTagPattern.ReplaceAllString(match[2], ""))
+		if label == "" || html.UnescapeString(label) == href {
+			return href
+		}
+		return label + " (" + href + ")"
+	})
+	// Los saltos de línea del HTML no se ven; solo cuentan los <br> y los bloques
+	text = whitespacePattern.ReplaceAllString(text, " ")
+	text = htmlBreakPattern.ReplaceAllString(text, "\n")
+	text = htmlBlockPattern.ReplaceAllString(text, "\n")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text) + "\n"
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "os"
+import (
+	"os"
+	"strconv"
+)
 
 func MustGetEnv(key string) string {
 	value, exists := os.LookupEnv(key)
@@ -9,3 +12,16 @@ func MustGetEnv(key string) string {
 	}
 	return value
 }
+
+// GetEnvInt lee una variable de entorno entera opcional, usando fallback si no está definida
+func GetEnvInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		panic("Environment variable " + key + " must be an integer")
+	}
+	return parsed
+}
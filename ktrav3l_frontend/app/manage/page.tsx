@@ -0,0 +1,261 @@
+'use client';
+
+import { Suspense, useEffect, useState } from 'react';
+import { useSearchParams } from 'next/navigation';
+import { Calendar } from '@/components/ui/calendar';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { Label } from '@/components/ui/label';
+import { Textarea } from '@/components/ui/textarea';
+import { format, parseISO } from 'date-fns';
+import { es } from 'date-fns/locale';
+import api from '@/lib/api';
+
+const statusLabels: Record<string, string> = {
+  pending: 'Pendiente',
+  approved: 'Aprobada',
+  rejected: 'Rechazada',
+  done: 'Completada',
+  cancelled: 'Cancelada',
+  no_show: 'No se presentó',
+};
+
+// Gestión de una reserva con el enlace del email de confirmación (?code=&token=):
+// el cliente puede cancelar o reprogramar su cita pendiente o aprobada
+function ManageAppointment() {
+  const searchParams = useSearchParams();
+  const code = searchParams.get('code') || '';
+  const token = searchParams.get('token') || '';
+  const lang = searchParams.get('lang') || 'es';
+  const [appointment, setAppointment] = useState<any>(null);
+  const [loading, setLoading] = useState(true);
+  const [working, setWorking] = useState(false);
+  const [error, setError] = useState('');
+  const [message, setMessage] = useState('');
+  const [reason, setReason] = useState('');
+  const [newDate, setNewDate] = useState<Date>();
+  const [slots, setSlots] = useState<any[]>([]);
+  const [newTime, setNewTime] = useState('');
+
+  const loadAppointment = async () => {
+    try {
+      const res = await api.get(`/appointments/short/${encodeURIComponent(code)}`, { params: { token, lang } });
+      setAppointment(res.data);
+    } catch (err: any) {
+      setError(err.response?.data?.error || 'No se encontró la reserva');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  useEffect(() => {
+    if (!code || !token) {
+      setError('El enlace de gestión no es válido');
+      setLoading(false);
+      return;
+    }
+    loadAppointment();
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [code, token]);
+
+  const handleDateSelect = async (date: Date | undefined) => {
+    if (!date) return;
+    setNewDate(date);
+    setNewTime('');
+
+    try {
+      const res = await api.get('/appointments/available-hours', {
+        params: { date: format(date, 'yyyy-MM-dd'), appointmentTypeID: appointment.appointmentTypeID, lang },
+      });
+      setSlots(res.data.availableSlots || []);
+    } catch (err: any) {
+      setSlots([]);
+      setError(err.response?.data?.error || 'Error cargando los horarios');
+    }
+  };
+
+  const handleCancel = async () => {
+    if (!window.confirm('¿Seguro que deseas cancelar tu cita?')) return;
+
+    setWorking(true);
+    setError('');
+    setMessage('');
+    try {
+      await api.post(`/appointments/short/${encodeURIComponent(code)}/cancel`, { token, reason }, { params: { lang } });
+      setMessage('Tu cita fue cancelada. Recibirás un email de confirmación.');
+      await loadAppointment();
+    } catch (err: any) {
+      setError(err.response?.data?.error || 'Error cancelando la cita');
+    } finally {
+      setWorking(false);
+    }
+  };
+
+  const handleReschedule = async () => {
+    if (!newDate || !newTime) {
+      setError('Selecciona la nueva fecha y hora');
+      return;
+    }
+
+    setWorking(true);
+    setError('');
+    setMessage('');
+    try {
+      await api.post(
+        `/appointments/short/${encodeURIComponent(code)}/reschedule`,
+        { token, newDate: format(newDate, 'yyyy-MM-dd'), newTime },
+        { params: { lang } },
+      );
+      setMessage('Tu cita fue reprogramada. Recibirás un email con la nueva fecha y hora.');
+      setNewDate(undefined);
+      setNewTime('');
+      setSlots([]);
+      await loadAppointment();
+    } catch (err: any) {
+      setError(err.response?.data?.error || 'Error reprogramando la cita');
+    } finally {
+      setWorking(false);
+    }
+  };
+
+  if (loading) {
+    return <p className="text-center text-muted-foreground">Cargando...</p>;
+  }
+
+  if (!appointment) {
+    return (
+      <Card>
+        <CardContent className="pt-6">
+          <div className="bg-red-50 border border-red-200 rounded-lg p-4">
+            <p className="text-red-800">{error}</p>
+          </div>
+        </CardContent>
+      </Card>
+    );
+  }
+
+  const canChange = appointment.status === 'pending' || appointment.status === 'approved';
+
+  return (
+    <div className="space-y-6">
+      <Card>
+        <CardHeader>
+          <CardTitle>Tu Reserva {appointment.shortID}</CardTitle>
+          <CardDescription>{appointment.appointmentType}</CardDescription>
+        </CardHeader>
+        <CardContent className="grid grid-cols-2 gap-4 text-sm">
+          <div>
+            <p className="text-muted-foreground">Nombre</p>
+            <p className="font-medium">{appointment.firstName} {appointment.lastName}</p>
+          </div>
+          <div>
+            <p className="text-muted-foreground">Estado</p>
+            <p className="font-medium">{statusLabels[appointment.status] || appointment.status}</p>
+          </div>
+          <div className="col-span-2">
+            <p className="text-muted-foreground">Fecha y hora</p>
+            <p className="font-medium">
+              {format(parseISO(appointment.appointmentDate), 'EEEE, dd \'de\' MMMM \'de\' yyyy', { locale: es })} a las {appointment.startTime}
+            </p>
+          </div>
+        </CardContent>
+      </Card>
+
+      {message && (
+        <div className="bg-green-50 border border-green-200 rounded-lg p-4">
+          <p className="text-green-900 font-medium">{message}</p>
+        </div>
+      )}
+      {error && <p className="text-red-500 text-sm">{error}</p>}
+
+      {canChange && (
+        <>
+          <Card>
+            <CardHeader>
+              <CardTitle>Reprogramar</CardTitle>
+              <CardDescription>Elige una nueva fecha y hora disponible</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+              <div className="flex justify-center">
+                <Calendar
+                  mode="single"
+                  selected={newDate}
+                  onSelect={handleDateSelect}
+                  disabled={(date) => {
+                    const today = new Date();
+                    today.setHours(0, 0, 0, 0);
+                    return date < today;
+                  }}
+                  className="rounded-md border pb-4"
+                />
+              </div>
+
+              {newDate && slots.length > 0 && (
+                <div className="grid grid-cols-3 gap-2">
+                  {slots.map((slot) => (
+                    <Button
+                      key={slot.startTime}
+                      variant={newTime === slot.startTime ? 'default' : 'outline'}
+                      onClick={() => setNewTime(slot.startTime)}
+                      size="sm"
+                    >
+                      {slot.startTime}
+                    </Button>
+                  ))}
+                </div>
+              )}
+              {newDate && slots.length === 0 && (
+                <p className="text-sm text-muted-foreground">No hay horas disponibles para esta fecha</p>
+              )}
+
+              <Button onClick={handleReschedule} disabled={working || !newDate || !newTime} className="w-full">
+                {working ? 'Guardando...' : 'Reprogramar Cita'}
+              </Button>
+            </CardContent>
+          </Card>
+
+          <Card>
+            <CardHeader>
+              <CardTitle>Cancelar</CardTitle>
+              <CardDescription>El horario quedará libre para otros clientes</CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+              <div className="space-y-1.5">
+                <Label htmlFor="reason">Motivo (opcional)</Label>
+                <Textarea id="reason" value={reason} onChange={(e) => setReason(e.target.value)} />
+              </div>
+              <Button variant="destructive" onClick={handleCancel} disabled={working} className="w-full">
+                {working ? 'Cancelando...' : 'Cancelar Cita'}
+              </Button>
+            </CardContent>
+          </Card>
+        </>
+      )}
+    </div>
+  );
+}
+
+export default function ManagePage() {
+  return (
+    <div className="min-h-screen bg-gray-50 py-12 px-4">
+      <div className="max-w-xl mx-auto">
+        <div className="text-center mb-8">
+          <div className="flex items-center justify-center gap-3 mb-4">
+            <img src="/logo.png" alt="KTravel" className="h-12 w-auto" />
+          </div>
+          <h1 className="text-3xl font-bold text-gray-900 mb-2">Gestionar Reserva</h1>
+        </div>
+
+        <Suspense fallback={<p className="text-center text-muted-foreground">Cargando...</p>}>
+          <ManageAppointment />
+        </Suspense>
+
+        <div className="text-center mt-6">
+          <Button variant="link" asChild>
+            <a href="/">Hacer una nueva reserva</a>
+          </Button>
+        </div>
+      </div>
+    </div>
+  );
+}
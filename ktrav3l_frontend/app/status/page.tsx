@@ -9,6 +9,7 @@ import api from '@/lib/api';
 
 export default function StatusPage() {
   const [shortID, setShortID] = useState('');
+  const [phoneLast4, setPhoneLast4] = useState('');
   const [appointment, setAppointment] = useState<any>(null);
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState('');
@@ -18,13 +19,20 @@ export default function StatusPage() {
       setError('Ingresa el código de reserva');
       return;
     }
+    // Los códigos anteriores de 8 caracteres requieren los últimos 4 dígitos del teléfono
+    if (shortID.length === 8 && phoneLast4.length !== 4) {
+      setError('Para códigos de 8 caracteres ingresa los últimos 4 dígitos de tu teléfono');
+      return;
+    }
 
     setLoading(true);
     setError('');
     setAppointment(null);
 
     try {
-      const res = await api.get(`/appointments/short/${shortID}`);
+      const res = await api.get(`/appointments/short/${shortID}`, {
+        params: phoneLast4 ? { phoneLast4 } : undefined,
+      });
       setAppointment(res.data);
     } catch (err: any) {
       setError(err.response?.data?.error || 'No se encontró la reserva');
@@ -58,7 +66,9 @@ export default function StatusPage() {
         <Card className="mb-6">
           <CardHeader>
             <CardTitle>Buscar Reserva</CardTitle>
-            <CardDescription>Ingresa el código de 8 caracteres que recibiste por email</CardDescription>
+            <CardDescription>
+              Ingresa el código de 10 caracteres que recibiste por email. Si tu código es de 8 caracteres, agrega también los últimos 4 dígitos de tu teléfono
+            </CardDescription>
           </CardHeader>
           <CardContent>
             <div className="flex gap-4">
@@ -68,8 +78,19 @@ export default function StatusPage() {
                   id="shortID"
                   value={shortID}
                   onChange={(e) => setShortID(e.target.value.trim())}
-                  placeholder="Ej. 28bb1fb7"
-                  maxLength={8}
+                  placeholder="Ej. K7M2XQ9PRT"
+                  maxLength={10}
+                />
+              </div>
+              <div className="w-40">
+                <Label htmlFor="phoneLast4">Últimos 4 del teléfono</Label>
+                <Input
+                  id="phoneLast4"
+                  value={phoneLast4}
+                  onChange={(e) => setPhoneLast4(e.target.value.replace(/\D/g, ''))}
+                  placeholder="Ej. 1234"
+                  inputMode="numeric"
+                  maxLength={4}
                 />
               </div>
               <Button onClick={handleSearch} disabled={loading} className="self-end">
@@ -146,7 +167,10 @@ export default function StatusPage() {
                 <p className="text-sm font-medium text-muted-foreground mb-2">Comprobante de Pago</p>
                 <Button
                   variant="outline"
-                  onClick={() => window.open(`${process.env.NEXT_PUBLIC_API_URL}/appointments/receipt/${appointment.shortID}`, '_blank')}
+                  onClick={() => {
+                    const query = phoneLast4 ? `?phoneLast4=${phoneLast4}` : '';
+                    window.open(`${process.env.NEXT_PUBLIC_API_URL}/appointments/receipt/${appointment.shortID}${query}`, '_blank');
+                  }}
                 >
                   Ver Comprobante
                 </Button>
@@ -0,0 +1,223 @@
+'use client';
+
+import { Suspense, useEffect, useState } from 'react';
+import { useSearchParams } from 'next/navigation';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import { Input } from '@/components/ui/input';
+import { Label } from '@/components/ui/label';
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select';
+import { format, parseISO } from 'date-fns';
+import { es } from 'date-fns/locale';
+import api from '@/lib/api';
+import { formatPhoneDisplay } from '@/lib/time-utils';
+
+// Permite tomar la franja ofrecida desde la lista de espera: la franja ya está reservada
+// para el cliente (holdToken) y solo falta elegir el banco y subir el comprobante
+function ClaimWaitlist() {
+  const searchParams = useSearchParams();
+  const token = searchParams.get('token') || '';
+  const lang = searchParams.get('lang') || 'es';
+  const [claim, setClaim] = useState<any>(null);
+  const [bankAccounts, setBankAccounts] = useState<any[]>([]);
+  const [selectedBankAccount, setSelectedBankAccount] = useState<any>(null);
+  const [receipt, setReceipt] = useState<File | null>(null);
+  const [loading, setLoading] = useState(true);
+  const [submitting, setSubmitting] = useState(false);
+  const [error, setError] = useState('');
+  const [shortID, setShortID] = useState('');
+
+  useEffect(() => {
+    if (!token) {
+      setError('El enlace no es válido');
+      setLoading(false);
+      return;
+    }
+
+    api
+      .get(`/appointments/waitlist/claim/${encodeURIComponent(token)}`, { params: { lang } })
+      .then((res) => setClaim(res.data))
+      .catch((err: any) => setError(err.response?.data?.error || 'No se encontró el horario ofrecido'))
+      .finally(() => setLoading(false));
+
+    api.get('/bank-accounts').then((res) => {
+      setBankAccounts(res.data.accounts || []);
+    });
+  }, [token, lang]);
+
+  const handleSubmit = async () => {
+    if (!selectedBankAccount) {
+      setError('Selecciona un banco');
+      return;
+    }
+    if (!receipt) {
+      setError('Debes subir el comprobante');
+      return;
+    }
+    if (receipt.size > 5 * 1024 * 1024) {
+      setError('El archivo debe ser menor a 5 MB');
+      return;
+    }
+
+    setSubmitting(true);
+    setError('');
+
+    try {
+      const formData = new FormData();
+      formData.append('firstName', claim.firstName);
+      formData.append('lastName', claim.lastName);
+      formData.append('email', claim.email);
+      formData.append('phoneNumber', claim.phoneNumber);
+      formData.append('appointmentDate', claim.appointmentDate);
+      formData.append('appointmentTime', claim.startTime);
+      formData.append('appointmentTypeID', claim.appointmentTypeID.toString());
+      formData.append('bankTransfer', selectedBankAccount.ID);
+      formData.append('holdToken', claim.holdToken);
+      formData.append('locale', lang);
+      formData.append('receipt', receipt);
+
+      const res = await api.post('/appointments', formData, {
+        headers: { 'Content-Type': 'multipart/form-data' },
+      });
+      setShortID(res.data.shortID);
+    } catch (err: any) {
+      setError(err.response?.data?.error || 'Error creando la reserva');
+    } finally {
+      setSubmitting(false);
+    }
+  };
+
+  if (loading) {
+    return <p className="text-center text-muted-foreground">Cargando...</p>;
+  }
+
+  if (shortID) {
+    return (
+      <Card>
+        <CardHeader>
+          <CardTitle>¡Reserva Creada Exitosamente!</CardTitle>
+          <CardDescription>
+            Tu código de reserva es: <span className="font-bold text-lg text-primary">{shortID}</span>
+          </CardDescription>
+        </CardHeader>
+        <CardContent>
+          <p className="text-sm text-muted-foreground">
+            Recibirás un email de confirmación. Tu reserva está pendiente de aprobación.
+          </p>
+        </CardContent>
+      </Card>
+    );
+  }
+
+  if (!claim) {
+    return (
+      <Card>
+        <CardContent className="pt-6">
+          <div className="bg-red-50 border border-red-200 rounded-lg p-4">
+            <p className="text-red-800">{error}</p>
+          </div>
+        </CardContent>
+      </Card>
+    );
+  }
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Horario Disponible</CardTitle>
+        <CardDescription>
+          Este horario está reservado para ti hasta el {format(new Date(claim.expiresAt), 'dd/MM/yyyy h:mm a')}. Completa el
+          pago para confirmar tu reserva.
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-5">
+        <div className="grid grid-cols-2 gap-4 text-sm">
+          <div>
+            <p className="text-muted-foreground">Nombre</p>
+            <p className="font-medium">{claim.firstName} {claim.lastName}</p>
+          </div>
+          <div>
+            <p className="text-muted-foreground">Tipo de servicio</p>
+            <p className="font-medium">{claim.appointmentType}</p>
+          </div>
+          <div>
+            <p className="text-muted-foreground">Email</p>
+            <p className="font-medium">{claim.email}</p>
+          </div>
+          <div>
+            <p className="text-muted-foreground">Teléfono</p>
+            <p className="font-medium">{formatPhoneDisplay(claim.phoneNumber)}</p>
+          </div>
+          <div className="col-span-2">
+            <p className="text-muted-foreground">Fecha y hora</p>
+            <p className="font-medium">
+              {format(parseISO(claim.appointmentDate), 'EEEE, dd \'de\' MMMM \'de\' yyyy', { locale: es })} a las {claim.startTime}
+            </p>
+          </div>
+        </div>
+
+        <div className="space-y-1.5">
+          <Label htmlFor="bankTransfer">Banco para Transferencia</Label>
+          <Select
+            onValueChange={(value) => setSelectedBankAccount(bankAccounts.find((acc) => acc.ID === value))}
+          >
+            <SelectTrigger className="w-full">
+              <SelectValue placeholder="Selecciona un banco" />
+            </SelectTrigger>
+            <SelectContent>
+              {bankAccounts.map((account) => (
+                <SelectItem key={account.ID} value={account.ID}>
+                  {account.BankName}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+          {selectedBankAccount && (
+            <div className="mt-2 p-3 bg-gray-50 rounded-md border">
+              <p className="text-sm font-medium text-gray-700">Número de Cuenta</p>
+              <p className="text-lg font-bold text-gray-900 font-mono">{selectedBankAccount.AccountNumber}</p>
+            </div>
+          )}
+        </div>
+
+        <div className="space-y-1.5">
+          <Label htmlFor="receipt">Comprobante de Pago (JPG, PNG, PDF)</Label>
+          <Input
+            id="receipt"
+            type="file"
+            accept=".jpg,.jpeg,.png,.pdf"
+            onChange={(e) => setReceipt(e.target.files?.[0] || null)}
+          />
+        </div>
+
+        {error && <p className="text-red-500 text-sm">{error}</p>}
+
+        <Button onClick={handleSubmit} disabled={submitting} className="w-full">
+          {submitting ? 'Creando...' : 'Confirmar Reserva'}
+        </Button>
+      </CardContent>
+    </Card>
+  );
+}
+
+export default function ClaimWaitlistPage() {
+  return (
+    <div className="min-h-screen bg-gray-50 py-12 px-4">
+      <div className="max-w-xl mx-auto">
+        <div className="flex items-center justify-center gap-3 mb-8">
+          <img src="/logo.png" alt="KTravel" className="h-12 w-auto" />
+        </div>
+
+        <Suspense fallback={<p className="text-center text-muted-foreground">Cargando...</p>}>
+          <ClaimWaitlist />
+        </Suspense>
+
+        <div className="text-center mt-6">
+          <Button variant="link" asChild>
+            <a href="/">Hacer una nueva reserva</a>
+          </Button>
+        </div>
+      </div>
+    </div>
+  );
+}
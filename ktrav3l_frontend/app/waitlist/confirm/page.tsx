@@ -0,0 +1,80 @@
+'use client';
+
+import { Suspense, useEffect, useState } from 'react';
+import { useSearchParams } from 'next/navigation';
+import { Card, CardContent, CardDescription, CardHeader, CardTitle } from '@/components/ui/card';
+import { Button } from '@/components/ui/button';
+import api from '@/lib/api';
+
+// Confirma el email de la lista de espera con el token del enlace enviado por email
+function ConfirmWaitlist() {
+  const searchParams = useSearchParams();
+  const token = searchParams.get('token') || '';
+  const lang = searchParams.get('lang') || 'es';
+  const [status, setStatus] = useState<'loading' | 'confirmed' | 'error'>('loading');
+  const [error, setError] = useState('');
+
+  useEffect(() => {
+    if (!token) {
+      setStatus('error');
+      setError('El enlace de confirmación no es válido');
+      return;
+    }
+
+    api
+      .post(`/appointments/waitlist/confirm/${encodeURIComponent(token)}`, null, { params: { lang } })
+      .then(() => setStatus('confirmed'))
+      .catch((err: any) => {
+        setStatus('error');
+        setError(err.response?.data?.error || 'No se pudo confirmar tu email');
+      });
+  }, [token, lang]);
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Lista de Espera</CardTitle>
+        <CardDescription>Confirmación de email</CardDescription>
+      </CardHeader>
+      <CardContent>
+        {status === 'loading' && <p className="text-muted-foreground">Confirmando tu email...</p>}
+
+        {status === 'confirmed' && (
+          <div className="bg-green-50 border border-green-200 rounded-lg p-4">
+            <p className="text-green-900 font-medium">
+              ✓ Tu email fue confirmado. Te avisaremos por email cuando se libere un horario en las fechas que elegiste.
+            </p>
+          </div>
+        )}
+
+        {status === 'error' && (
+          <div className="bg-red-50 border border-red-200 rounded-lg p-4">
+            <p className="text-red-800">{error}</p>
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+export default function ConfirmWaitlistPage() {
+  return (
+    <div className="min-h-screen bg-gray-50 py-12 px-4">
+      <div className="max-w-xl mx-auto">
+        <div className="flex items-center justify-center gap-3 mb-8">
+          <img src="/logo.png" alt="KTravel" className="h-12 w-auto" />
+        </div>
+
+        <Suspense fallback={<p className="text-center text-muted-foreground">Cargando...</p>}>
+          <ConfirmWaitlist />
+        </Suspense>
+
+        <div className="text-center mt-6">
+          <Button variant="link" asChild>
+            <a href="/">Hacer una nueva reserva</a>
+          </Button>
+        </div>
+      </div>
+    </div>
+  );
+}